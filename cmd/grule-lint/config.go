@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/adarshjos/grule-lint/internal/config"
+)
+
+// newConfigCmd creates the "config" command group. Running it without a
+// subcommand is an error, so that a mistyped lint run does not pass.
+func newConfigCmd() *cobra.Command {
+	configCmd := &cobra.Command{
+		Use:   "config",
+		Short: "Work with grule-lint configuration files",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return fmt.Errorf("%s requires a subcommand; to lint a directory named config, run grule-lint ./config", cmd.CommandPath())
+		},
+	}
+
+	configCmd.AddCommand(&cobra.Command{
+		Use:   "validate [config file]",
+		Short: "Check a configuration file for errors",
+		Long: `Checks a configuration file and the configs it extends for unknown keys,
+unknown rule IDs, invalid severities and invalid naming conventions.
+
+Without an argument, the ` + config.ConfigFileName + ` file for the current directory is used.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runConfigValidate,
+	})
+
+	return configCmd
+}
+
+func runConfigValidate(cmd *cobra.Command, args []string) error {
+	path := ""
+	if len(args) > 0 {
+		path = args[0]
+	} else {
+		found, ok, err := config.FindConfigFile(".")
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("no %s found in the current directory or its parents", config.ConfigFileName)
+		}
+		path = found
+	}
+
+	problems, err := config.ValidateFile(path)
+	if err != nil {
+		return err
+	}
+
+	if len(problems) == 0 {
+		fmt.Printf("%s: configuration is valid\n", path)
+		return nil
+	}
+
+	for _, p := range problems {
+		fmt.Println(p)
+	}
+	fmt.Printf("\nFound %d problem(s)\n", len(problems))
+	os.Exit(1)
+	return nil
+}
@@ -2,16 +2,20 @@ package main
 
 import (
 	"fmt"
-	"io"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/adarshjos/grule-lint/internal/baseline"
 	"github.com/adarshjos/grule-lint/internal/config"
 	"github.com/adarshjos/grule-lint/internal/diagnostic"
+	"github.com/adarshjos/grule-lint/internal/discovery"
 	"github.com/adarshjos/grule-lint/internal/linter"
 	"github.com/adarshjos/grule-lint/internal/reporter"
+	"github.com/adarshjos/grule-lint/internal/rules"
+	"github.com/adarshjos/grule-lint/internal/schema"
 )
 
 var (
@@ -21,12 +25,21 @@ var (
 	buildTime = "unknown"
 
 	// CLI flags
-	configFlag  string
-	outputFlag  string
-	ruleFlags   []string
-	excludeFlag []string
-	quietFlag   bool
-	noColorFlag bool
+	configFlag    string
+	outputFlag    string
+	formatFlag    string
+	templateFlag  string
+	reportFlags   []string
+	baselineFlag  string
+	writeBaseFlag string
+	ruleFlags     []string
+	excludeFlag   []string
+	quietFlag     bool
+	noColorFlag   bool
+	gitignoreFlag bool
+	schemaFlag    string
+	factsPkgFlag  string
+	factFlags     []string
 )
 
 func main() {
@@ -42,7 +55,15 @@ Examples:
   grule-lint rules/
   grule-lint rules/*.grl
   grule-lint --config .grl-lint.yaml rules/
-  grule-lint --quiet rules/`,
+  grule-lint --quiet rules/
+  grule-lint --format json --output results.json rules/
+  grule-lint --format sarif --output results.sarif rules/
+  grule-lint --format html --output report.html rules/
+  grule-lint --template summary.tmpl rules/
+  grule-lint --report text --report sarif=results.sarif rules/
+  grule-lint --write-baseline baseline.json rules/
+  grule-lint --baseline baseline.json rules/
+  grule-lint config validate .grl-lint.yaml`,
 		Version: fmt.Sprintf("%s (commit: %s, built: %s)", version, commit, buildTime),
 		Args:    cobra.MinimumNArgs(1),
 		RunE:    runLint,
@@ -51,39 +72,101 @@ Examples:
 	// Add flags
 	rootCmd.Flags().StringVarP(&configFlag, "config", "c", "", "Path to config file (default: .grl-lint.yaml)")
 	rootCmd.Flags().StringVarP(&outputFlag, "output", "o", "", "Output file (default: stdout)")
+	rootCmd.Flags().StringVarP(&formatFlag, "format", "f", "", "Output format ("+strings.Join(reporter.DefaultRegistry().Formats(), ", ")+"; default: github under GitHub Actions, text otherwise)")
+	rootCmd.Flags().StringArrayVar(&reportFlags, "report", nil, "Output as format[=file], e.g. --report text --report sarif=out.sarif (can be repeated; replaces --format/--output)")
+	rootCmd.Flags().StringVar(&templateFlag, "template", "", "Render output with a Go text/template file (implies --format template)")
+	rootCmd.Flags().StringVar(&baselineFlag, "baseline", "", "Only report issues not recorded in this baseline file")
+	rootCmd.Flags().StringVar(&writeBaseFlag, "write-baseline", "", "Record all current issues in a baseline file and exit")
 	rootCmd.Flags().StringArrayVarP(&ruleFlags, "rule", "r", nil, "Enable only specific rules (can be repeated)")
 	rootCmd.Flags().StringArrayVarP(&excludeFlag, "exclude", "e", nil, "Exclude file patterns (can be repeated)")
+	rootCmd.Flags().BoolVar(&gitignoreFlag, "gitignore", false, "Also skip files ignored by .gitignore")
+	rootCmd.Flags().StringVar(&schemaFlag, "schema", "", "Fact schema file (YAML or JSON) describing the DataContext")
+	rootCmd.Flags().StringVar(&factsPkgFlag, "facts-package", "", "Directory of the Go package declaring the fact types named by --fact")
+	rootCmd.Flags().StringArrayVar(&factFlags, "fact", nil, "Fact derived from a Go type as Name=Type, e.g. --fact Order=OrderFact (can be repeated)")
 	rootCmd.Flags().BoolVarP(&quietFlag, "quiet", "q", false, "Only show errors, not warnings/info")
 	rootCmd.Flags().BoolVar(&noColorFlag, "no-color", false, "Disable colored output")
 
+	configCmd := newConfigCmd()
+	rootCmd.AddCommand(configCmd)
+
+	// Rules kept in a config directory are linted as before the config
+	// command existed, unless a config subcommand is named
+	if cmd, _, err := rootCmd.Find(os.Args[1:]); err == nil && cmd == configCmd {
+		if _, err := os.Stat(configCmd.Name()); err == nil {
+			rootCmd.RemoveCommand(configCmd)
+		}
+	}
+
 	if err := rootCmd.Execute(); err != nil {
 		os.Exit(1)
 	}
 }
 
 func runLint(cmd *cobra.Command, args []string) error {
+	// Resolve and validate the requested outputs before doing any work
+	specs, err := resolveReportSpecs(cmd)
+	if err != nil {
+		return err
+	}
+
+	tmpl, err := loadReportTemplate(specs)
+	if err != nil {
+		return err
+	}
+
+	if baselineFlag != "" && writeBaseFlag != "" {
+		return fmt.Errorf("--baseline cannot be combined with --write-baseline")
+	}
+
 	// Load configuration
 	cfg, err := loadConfig(args)
 	if err != nil {
 		return fmt.Errorf("loading config: %w", err)
 	}
+	for _, w := range cfg.Warnings {
+		fmt.Fprintf(os.Stderr, "warning: %s\n", w)
+	}
 
 	// Apply CLI overrides to config
-	applyCliOverrides(cfg)
+	if err := applyCliOverrides(cfg); err != nil {
+		return err
+	}
 
-	// Create linter
-	l := linter.New()
+	factSchema, err := cfg.LoadSchema()
+	if err != nil {
+		return fmt.Errorf("loading schema: %w", err)
+	}
 
-	// Filter paths based on exclusions
-	paths := filterPaths(args, cfg)
+	// Create linter, resolving rule settings per file
+	l := linter.NewWithConfigResolver(func(file string) rules.RegistryConfig {
+		fileCfg := cfg.ForFile(file)
+		return rules.RegistryConfig{
+			NamingConvention: fileCfg.Naming.Convention,
+			MaxConditions:    fileCfg.Complexity.MaxConditions,
+			RuleOptions:      fileCfg.RuleOptions,
+			Rules:            fileCfg.Rules,
+			Schema:           factSchema,
+		}
+	})
+	l.SetKnowledgeBases(cfg.KnowledgeBases)
+
+	// Find files, applying include/exclude patterns and ignore files
+	files, err := discovery.New(discovery.Options{
+		Include:   cfg.Include,
+		Exclude:   cfg.Exclude,
+		Gitignore: cfg.Gitignore || gitignoreFlag,
+	}).Find(args)
+	if err != nil {
+		return fmt.Errorf("finding files: %w", err)
+	}
 
-	if len(paths) == 0 {
+	if len(files) == 0 {
 		fmt.Println("No files to lint after applying exclusions.")
 		return nil
 	}
 
-	// Lint all provided paths
-	diagnostics, err := l.LintPaths(paths)
+	// Lint all discovered files
+	diagnostics, err := l.LintFiles(files)
 	if err != nil {
 		return fmt.Errorf("linting failed: %w", err)
 	}
@@ -91,33 +174,33 @@ func runLint(cmd *cobra.Command, args []string) error {
 	// Filter diagnostics based on config
 	filtered := filterDiagnostics(diagnostics.All(), cfg)
 
-	// Set up output writer
-	var output io.Writer = os.Stdout
-	if outputFlag != "" {
-		file, err := os.Create(outputFlag)
-		if err != nil {
-			return fmt.Errorf("creating output file: %w", err)
+	// Record the current diagnostics instead of reporting them
+	if writeBaseFlag != "" {
+		b := baseline.New(filepath.Dir(writeBaseFlag), filtered, l.RuleAt)
+		if err := b.Save(writeBaseFlag); err != nil {
+			return err
 		}
-		defer func() { _ = file.Close() }()
-		output = file
+		fmt.Fprintf(os.Stderr, "Wrote %d issue(s) to baseline %s\n", b.Count(), writeBaseFlag)
+		return nil
 	}
 
-	// Create text reporter
-	useColors := !noColorFlag && outputFlag == "" && isTerminal()
-	rep := reporter.NewTextReporter(output, useColors)
+	// Drop diagnostics already recorded in the baseline
+	if baselineFlag != "" {
+		b, err := baseline.Load(baselineFlag)
+		if err != nil {
+			return err
+		}
+		filtered = b.Filter(filtered, l.RuleAt)
+	}
 
 	// Create a new DiagnosticSet from filtered diagnostics
 	ds := diagnostic.NewDiagnosticSet()
+	ds.AddFiles(diagnostics.Files()...)
 	ds.AddAll(filtered)
 
-	if err := rep.Report(ds); err != nil {
-		return fmt.Errorf("reporting failed: %w", err)
-	}
-
-	if ds.Count() == 0 {
-		if _, err := fmt.Fprintln(output, "No issues found."); err != nil {
-			return fmt.Errorf("writing output: %w", err)
-		}
+	// Render the same diagnostics to every requested output
+	if err := writeReports(specs, ds, l, tmpl); err != nil {
+		return err
 	}
 
 	// Exit with error code if there are errors
@@ -152,7 +235,22 @@ func loadConfig(args []string) (*config.Config, error) {
 }
 
 // applyCliOverrides applies CLI flags to the config.
-func applyCliOverrides(cfg *config.Config) {
+func applyCliOverrides(cfg *config.Config) error {
+	if schemaFlag != "" {
+		cfg.Schema = schemaFlag
+	}
+
+	if factsPkgFlag != "" {
+		cfg.GoFacts.Package = factsPkgFlag
+	}
+	if len(factFlags) > 0 {
+		facts, err := schema.ParseFactTypes(factFlags)
+		if err != nil {
+			return err
+		}
+		cfg.Merge(&config.Config{GoFacts: config.GoFactsConfig{Facts: facts}})
+	}
+
 	// Add CLI exclude patterns
 	if len(excludeFlag) > 0 {
 		cfg.Exclude = append(cfg.Exclude, excludeFlag...)
@@ -167,57 +265,36 @@ func applyCliOverrides(cfg *config.Config) {
 		}
 
 		// Disable all rules that are not in the enabled set
-		allRuleIDs := []string{
-			"GRL001", "GRL002", "GRL003", "GRL004", "GRL005", "GRL006",
-			"GRL007", "GRL008", "GRL009", "GRL010", "GRL011", "GRL012",
-		}
-		for _, ruleID := range allRuleIDs {
+		for ruleID := range rules.DefaultRegistry().AllRules() {
 			if !enabledRules[ruleID] {
 				cfg.Rules[ruleID] = "off"
 			}
 		}
 	}
-}
-
-// filterPaths filters file paths based on exclusions.
-func filterPaths(args []string, cfg *config.Config) []string {
-	var result []string
-
-	for _, path := range args {
-		info, err := os.Stat(path)
-		if err != nil {
-			// Keep invalid paths - let the linter handle the error
-			result = append(result, path)
-			continue
-		}
-
-		if info.IsDir() {
-			// For directories, we'll let the linter walk them
-			// The linter will apply exclusions per-file
-			result = append(result, path)
-		} else {
-			// Check if file should be excluded
-			if !cfg.ShouldExclude(path) {
-				result = append(result, path)
-			}
-		}
-	}
 
-	return result
+	return nil
 }
 
 // filterDiagnostics filters diagnostics based on config and quiet mode.
 func filterDiagnostics(diags []diagnostic.Diagnostic, cfg *config.Config) []diagnostic.Diagnostic {
 	var result []diagnostic.Diagnostic
+	fileConfigs := make(map[string]*config.Config)
 
 	for _, d := range diags {
+		// Resolve path-specific overrides once per file
+		fileCfg, ok := fileConfigs[d.File]
+		if !ok {
+			fileCfg = cfg.ForFile(d.File)
+			fileConfigs[d.File] = fileCfg
+		}
+
 		// Check if rule is enabled
-		if !cfg.IsRuleEnabled(d.RuleID) {
+		if !fileCfg.IsRuleEnabled(d.RuleID) {
 			continue
 		}
 
 		// Apply severity override
-		severity := cfg.GetRuleSeverity(d.RuleID, d.Severity)
+		severity := fileCfg.GetRuleSeverity(d.RuleID, d.Severity)
 		if severity == nil {
 			continue // Rule disabled
 		}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/cobra"
+
+	"github.com/adarshjos/grule-lint/internal/diagnostic"
+	"github.com/adarshjos/grule-lint/internal/linter"
+	"github.com/adarshjos/grule-lint/internal/reporter"
+)
+
+// reportSpec is a single requested output: a format and an optional
+// destination file. An empty path means stdout.
+type reportSpec struct {
+	format string
+	path   string
+}
+
+// parseReportSpec parses a --report value of the form "format[=file]".
+func parseReportSpec(value string) reportSpec {
+	format, path, _ := strings.Cut(value, "=")
+	return reportSpec{format: strings.TrimSpace(format), path: strings.TrimSpace(path)}
+}
+
+// resolveReportSpecs returns the outputs requested on the command line.
+// Repeated --report flags take precedence; otherwise --format and --output
+// describe a single output.
+func resolveReportSpecs(cmd *cobra.Command) ([]reportSpec, error) {
+	var specs []reportSpec
+
+	if len(reportFlags) > 0 {
+		if cmd.Flags().Changed("format") || cmd.Flags().Changed("output") {
+			return nil, fmt.Errorf("--report cannot be combined with --format or --output")
+		}
+		for _, value := range reportFlags {
+			specs = append(specs, parseReportSpec(value))
+		}
+	} else {
+		specs = append(specs, reportSpec{format: resolveFormat(cmd), path: outputFlag})
+	}
+
+	reporters := reporter.DefaultRegistry()
+	destinations := make(map[string]bool)
+	for _, spec := range specs {
+		if !reporters.Has(spec.format) {
+			return nil, fmt.Errorf("unknown output format %q (valid: %s)", spec.format, strings.Join(reporters.Formats(), ", "))
+		}
+
+		dest := spec.path
+		if dest == "" {
+			dest = "stdout"
+		}
+		if destinations[dest] {
+			return nil, fmt.Errorf("more than one report writes to %s", dest)
+		}
+		destinations[dest] = true
+	}
+
+	return specs, nil
+}
+
+// resolveFormat returns the output format to use when --report is not
+// given. An explicit --format always wins; otherwise GitHub Actions runs
+// get workflow-command output.
+func resolveFormat(cmd *cobra.Command) string {
+	if cmd.Flags().Changed("format") {
+		return formatFlag
+	}
+	if templateFlag != "" {
+		return "template"
+	}
+	if os.Getenv("GITHUB_ACTIONS") == "true" {
+		return "github"
+	}
+	return "text"
+}
+
+// loadReportTemplate parses --template if any requested output uses the
+// template format, so that a broken template fails before linting.
+func loadReportTemplate(specs []reportSpec) (*template.Template, error) {
+	for _, spec := range specs {
+		if spec.format != "template" {
+			continue
+		}
+		if templateFlag == "" {
+			return nil, fmt.Errorf("the template format requires --template")
+		}
+		return reporter.ParseTemplateFile(templateFlag)
+	}
+	return nil, nil
+}
+
+// writeReports renders the diagnostics once per requested output.
+func writeReports(specs []reportSpec, ds *diagnostic.DiagnosticSet, l *linter.Linter, tmpl *template.Template) error {
+	reporters := reporter.DefaultRegistry()
+
+	for _, spec := range specs {
+		if err := writeReport(reporters, spec, ds, l, tmpl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeReport renders the diagnostics for a single output.
+func writeReport(reporters *reporter.Registry, spec reportSpec, ds *diagnostic.DiagnosticSet, l *linter.Linter, tmpl *template.Template) error {
+	var output io.Writer = os.Stdout
+	if spec.path != "" {
+		file, err := os.Create(spec.path)
+		if err != nil {
+			return fmt.Errorf("creating output file: %w", err)
+		}
+		defer func() { _ = file.Close() }()
+		output = file
+	}
+
+	rep, err := reporters.New(spec.format, reporter.Options{
+		Writer:      output,
+		Colorized:   !noColorFlag && spec.path == "" && isTerminal(),
+		Sources:     l.Source,
+		Registry:    l.Registry(),
+		ToolVersion: version,
+		Template:    tmpl,
+	})
+	if err != nil {
+		return fmt.Errorf("creating %s reporter: %w", spec.format, err)
+	}
+
+	if err := rep.Report(ds); err != nil {
+		return fmt.Errorf("reporting failed: %w", err)
+	}
+
+	if ds.Count() == 0 && (spec.format == "text" || spec.format == "pretty") {
+		if _, err := fmt.Fprintln(output, "No issues found."); err != nil {
+			return fmt.Errorf("writing output: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,196 @@
+// Package baseline records known diagnostics so that only new findings are
+// reported when adopting grule-lint on an existing rule set.
+package baseline
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/adarshjos/grule-lint/internal/diagnostic"
+)
+
+// Version is the baseline file format version.
+const Version = 1
+
+// RuleLookup returns the name of the GRL rule enclosing a position, or an
+// empty string if the position is outside any rule.
+type RuleLookup func(file string, pos diagnostic.Position) string
+
+// Entry is a fingerprint of one or more identical diagnostics. Line numbers
+// are deliberately left out so entries survive rules moving within a file.
+type Entry struct {
+	File    string `json:"file"`
+	Rule    string `json:"rule,omitempty"`
+	RuleID  string `json:"ruleId"`
+	Message string `json:"message"`
+	Count   int    `json:"count"`
+}
+
+// Baseline is a set of known diagnostics.
+type Baseline struct {
+	// root is the directory file paths are relative to.
+	root   string
+	counts map[Entry]int
+}
+
+type baselineFile struct {
+	Version int     `json:"version"`
+	Entries []Entry `json:"entries"`
+}
+
+// numberPatterns match position references inside diagnostic messages.
+var numberPatterns = []struct {
+	re          *regexp.Regexp
+	replacement string
+}{
+	{regexp.MustCompile(`\bline \d+`), "line N"},
+	{regexp.MustCompile(`\b\d+:\d+\b`), "N:N"},
+	// file:line references to other files, as in cross-file findings
+	{regexp.MustCompile(`(\S):\d+\b`), "$1:N"},
+}
+
+// New creates a baseline from diagnostics. File paths are recorded relative
+// to root, normally the directory of the baseline file.
+func New(root string, diags []diagnostic.Diagnostic, lookup RuleLookup) *Baseline {
+	b := &Baseline{root: root, counts: make(map[Entry]int)}
+	for _, d := range diags {
+		b.counts[b.fingerprint(d, lookup)]++
+	}
+	return b
+}
+
+// Load reads a baseline file.
+func Load(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading baseline file %s: %w", path, err)
+	}
+
+	var file baselineFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parsing baseline file %s: %w", path, err)
+	}
+	if file.Version != Version {
+		return nil, fmt.Errorf("baseline file %s has unsupported version %d", path, file.Version)
+	}
+
+	b := &Baseline{root: filepath.Dir(path), counts: make(map[Entry]int)}
+	for _, e := range file.Entries {
+		count := e.Count
+		if count < 1 {
+			count = 1
+		}
+		e.Count = 0
+		b.counts[e] += count
+	}
+	return b, nil
+}
+
+// Save writes the baseline to a file.
+func (b *Baseline) Save(path string) error {
+	data, err := json.MarshalIndent(baselineFile{Version: Version, Entries: b.Entries()}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding baseline: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("writing baseline file %s: %w", path, err)
+	}
+	return nil
+}
+
+// Entries returns the baseline entries sorted by file, rule ID, rule name
+// and message.
+func (b *Baseline) Entries() []Entry {
+	entries := make([]Entry, 0, len(b.counts))
+	for e, count := range b.counts {
+		e.Count = count
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		a, c := entries[i], entries[j]
+		if a.File != c.File {
+			return a.File < c.File
+		}
+		if a.RuleID != c.RuleID {
+			return a.RuleID < c.RuleID
+		}
+		if a.Rule != c.Rule {
+			return a.Rule < c.Rule
+		}
+		return a.Message < c.Message
+	})
+	return entries
+}
+
+// Count returns the total number of diagnostics in the baseline.
+func (b *Baseline) Count() int {
+	total := 0
+	for _, count := range b.counts {
+		total += count
+	}
+	return total
+}
+
+// Filter returns the diagnostics that are not in the baseline. If a
+// fingerprint occurs more often than recorded, the extra occurrences are
+// reported as new.
+func (b *Baseline) Filter(diags []diagnostic.Diagnostic, lookup RuleLookup) []diagnostic.Diagnostic {
+	remaining := make(map[Entry]int, len(b.counts))
+	for e, count := range b.counts {
+		remaining[e] = count
+	}
+
+	var result []diagnostic.Diagnostic
+	for _, d := range diags {
+		key := b.fingerprint(d, lookup)
+		if remaining[key] > 0 {
+			remaining[key]--
+			continue
+		}
+		result = append(result, d)
+	}
+	return result
+}
+
+// fingerprint returns the line-independent key of a diagnostic.
+func (b *Baseline) fingerprint(d diagnostic.Diagnostic, lookup RuleLookup) Entry {
+	e := Entry{
+		File:    b.relativePath(d.File),
+		RuleID:  d.RuleID,
+		Message: normalizeMessage(d.Message),
+	}
+	if lookup != nil {
+		e.Rule = lookup(d.File, d.Range.Start)
+	}
+	return e
+}
+
+// relativePath returns file relative to the baseline root, using forward
+// slashes so baselines are portable across platforms.
+func (b *Baseline) relativePath(file string) string {
+	absFile, err := filepath.Abs(file)
+	if err != nil {
+		return filepath.ToSlash(file)
+	}
+	absRoot, err := filepath.Abs(b.root)
+	if err != nil {
+		return filepath.ToSlash(file)
+	}
+	rel, err := filepath.Rel(absRoot, absFile)
+	if err != nil {
+		return filepath.ToSlash(file)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// normalizeMessage removes line and column references from a message.
+func normalizeMessage(message string) string {
+	for _, p := range numberPatterns {
+		message = p.re.ReplaceAllString(message, p.replacement)
+	}
+	return message
+}
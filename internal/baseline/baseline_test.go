@@ -0,0 +1,111 @@
+package baseline
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/adarshjos/grule-lint/internal/diagnostic"
+)
+
+func diagAt(file, ruleID, message string, line int) diagnostic.Diagnostic {
+	pos := diagnostic.Position{Line: line, Column: 1}
+	return diagnostic.Diagnostic{
+		File:     file,
+		Range:    diagnostic.Range{Start: pos, End: pos},
+		RuleID:   ruleID,
+		Severity: diagnostic.SeverityWarning,
+		Message:  message,
+	}
+}
+
+// ruleByLine maps lines to GRL rule names for tests.
+func ruleByLine(rules map[int]string) RuleLookup {
+	return func(_ string, pos diagnostic.Position) string {
+		return rules[pos.Line]
+	}
+}
+
+func TestBaseline_FilterIgnoresLineMoves(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "rules", "order.grl")
+	path := filepath.Join(dir, "baseline.json")
+
+	before := []diagnostic.Diagnostic{
+		diagAt(file, "GRL004", "Rule 'A' does not call Retract()", 3),
+		diagAt(file, "GRL005", "Duplicate rule name 'A' (first defined at line 1)", 8),
+	}
+	if err := New(dir, before, ruleByLine(map[int]string{3: "A", 8: "A"})).Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	b, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	// Same issues after inserting lines above, plus a new one
+	after := []diagnostic.Diagnostic{
+		diagAt(file, "GRL002", "Rule 'B' is missing a description", 1),
+		diagAt(file, "GRL004", "Rule 'A' does not call Retract()", 13),
+		diagAt(file, "GRL005", "Duplicate rule name 'A' (first defined at line 6)", 18),
+	}
+	remaining := b.Filter(after, ruleByLine(map[int]string{1: "B", 13: "A", 18: "A"}))
+
+	if len(remaining) != 1 || remaining[0].RuleID != "GRL002" {
+		t.Errorf("expected only the new GRL002, got %+v", remaining)
+	}
+}
+
+func TestBaseline_FilterIgnoresLineMovesInOtherFiles(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "shared.grl")
+	lookup := ruleByLine(map[int]string{4: "A", 9: "A"})
+
+	b := New(dir, []diagnostic.Diagnostic{
+		diagAt(file, "GRL005", "Duplicate rule name 'A' in knowledge base 'KB' (first defined in rules/pricing.grl:2)", 4),
+		diagAt(file, "GRL012", "Rule 'A' has the same when clause as 'B' (in rules/pricing.grl:7) in knowledge base 'KB' - this may cause conflicts", 4),
+	}, lookup)
+
+	// The rules moved in the other file and in this one
+	remaining := b.Filter([]diagnostic.Diagnostic{
+		diagAt(file, "GRL005", "Duplicate rule name 'A' in knowledge base 'KB' (first defined in rules/pricing.grl:12)", 9),
+		diagAt(file, "GRL012", "Rule 'A' has the same when clause as 'B' (in rules/pricing.grl:17) in knowledge base 'KB' - this may cause conflicts", 9),
+	}, lookup)
+
+	if len(remaining) != 0 {
+		t.Errorf("expected cross-file findings to match the baseline, got %+v", remaining)
+	}
+}
+
+func TestBaseline_FilterCountsOccurrences(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "order.grl")
+	lookup := ruleByLine(map[int]string{2: "A", 5: "A"})
+
+	b := New(dir, []diagnostic.Diagnostic{
+		diagAt(file, "GRL008", "Variable 'X' is assigned but never used", 2),
+	}, lookup)
+
+	remaining := b.Filter([]diagnostic.Diagnostic{
+		diagAt(file, "GRL008", "Variable 'X' is assigned but never used", 2),
+		diagAt(file, "GRL008", "Variable 'X' is assigned but never used", 5),
+	}, lookup)
+
+	if len(remaining) != 1 || remaining[0].Range.Start.Line != 5 {
+		t.Errorf("expected the second occurrence to be new, got %+v", remaining)
+	}
+	if b.Count() != 1 {
+		t.Errorf("expected baseline count 1, got %d", b.Count())
+	}
+}
+
+func TestLoad_UnsupportedVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	if err := os.WriteFile(path, []byte(`{"version": 99, "entries": []}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("expected error for unsupported version")
+	}
+}
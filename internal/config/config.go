@@ -7,7 +7,6 @@ import (
 	"path/filepath"
 
 	"github.com/bmatcuk/doublestar"
-	"gopkg.in/yaml.v3"
 
 	"github.com/adarshjos/grule-lint/internal/diagnostic"
 )
@@ -15,11 +14,59 @@ import (
 const ConfigFileName = ".grl-lint.yaml"
 
 type Config struct {
-	Rules      map[string]string `yaml:"rules"`
+	Extends    []string          `yaml:"extends"`
+	Rules      map[string]string `yaml:"-"`
 	Exclude    []string          `yaml:"exclude"`
 	Include    []string          `yaml:"include"`
+	Gitignore  bool              `yaml:"gitignore"`
+	Schema     string            `yaml:"schema"`
+	GoFacts    GoFactsConfig     `yaml:"go_facts"`
 	Complexity ComplexityConfig  `yaml:"complexity"`
 	Naming     NamingConfig      `yaml:"naming"`
+	Overrides  []Override        `yaml:"overrides"`
+
+	// KnowledgeBases lists the files loaded together into one knowledge
+	// base, which are checked together for cross-file issues.
+	KnowledgeBases []KnowledgeBaseConfig `yaml:"knowledge_bases"`
+
+	// RuleOptions holds the options set under rules, keyed by rule ID and
+	// option name. Both Rules and RuleOptions are decoded from the rules
+	// block by UnmarshalYAML.
+	RuleOptions map[string]map[string]any `yaml:"-"`
+
+	// Warnings lists the unknown keys of the loaded config files, which
+	// are ignored. config validate reports them as errors.
+	Warnings []Problem `yaml:"-"`
+}
+
+// Override applies rule settings to files matching glob patterns. Later
+// overrides take precedence over earlier ones.
+type Override struct {
+	Files       []string                  `yaml:"files"`
+	Rules       map[string]string         `yaml:"-"`
+	RuleOptions map[string]map[string]any `yaml:"-"`
+	Complexity  ComplexityConfig          `yaml:"complexity"`
+	Naming      NamingConfig              `yaml:"naming"`
+}
+
+// DefaultKnowledgeBaseVersion is used for knowledge bases without a version.
+const DefaultKnowledgeBaseVersion = "1.0.0"
+
+// KnowledgeBaseConfig describes a Grule knowledge base built from several
+// files under the same name and version.
+type KnowledgeBaseConfig struct {
+	Name    string   `yaml:"name"`
+	Version string   `yaml:"version"`
+	Files   []string `yaml:"files"`
+}
+
+// GoFactsConfig derives fact schemas from Go struct types.
+type GoFactsConfig struct {
+	// Package is the directory of the Go package declaring the fact types.
+	Package string `yaml:"package"`
+
+	// Facts maps DataContext names to type names in the package.
+	Facts map[string]string `yaml:"facts"`
 }
 
 type ComplexityConfig struct {
@@ -36,47 +83,68 @@ type NamingConfig struct {
 
 func DefaultConfig() *Config {
 	return &Config{
-		Rules:      make(map[string]string),
-		Exclude:    []string{},
-		Include:    []string{"**/*.grl"},
-		Complexity: ComplexityConfig{MaxConditions: 5},
-		Naming:     NamingConfig{Convention: "PascalCase"},
+		Rules:       make(map[string]string),
+		RuleOptions: make(map[string]map[string]any),
+		Exclude:     []string{},
+		Include:     []string{"**/*.grl"},
+		Complexity:  ComplexityConfig{MaxConditions: 5},
+		Naming:      NamingConfig{Convention: "PascalCase"},
 	}
 }
 
+// Load reads a config file. Configs listed under extends are merged in
+// order before the file's own settings, on top of the defaults.
+// Unknown keys are ignored and listed in Warnings; other invalid settings
+// are returned as a *ValidationError.
 func Load(path string) (*Config, error) {
-	data, err := os.ReadFile(path)
+	return load(&loader{}, path)
+}
+
+// load reads a config file with a loader and applies the defaults.
+func load(ld *loader, path string) (*Config, error) {
+	loaded, err := ld.loadFile(path)
 	if err != nil {
-		return nil, fmt.Errorf("reading config file %s: %w", path, err)
+		return nil, err
 	}
 
 	config := DefaultConfig()
-	if err := yaml.Unmarshal(data, config); err != nil {
-		return nil, fmt.Errorf("parsing config file %s: %w", path, err)
-	}
+	config.Merge(loaded)
+	config.Warnings = ld.warnings
 	return config, nil
 }
 
 func LoadFromDirectory(dir string) (*Config, error) {
+	configPath, found, err := FindConfigFile(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return DefaultConfig(), nil
+	}
+	return Load(configPath)
+}
+
+// FindConfigFile searches for a config file starting from dir and walking
+// up to parent directories.
+func FindConfigFile(dir string) (string, bool, error) {
 	absDir, err := filepath.Abs(dir)
 	if err != nil {
-		return nil, fmt.Errorf("resolving directory path %s: %w", dir, err)
+		return "", false, fmt.Errorf("resolving directory path %s: %w", dir, err)
 	}
 
 	current := absDir
 	for {
 		configPath := filepath.Join(current, ConfigFileName)
 		if _, err := os.Stat(configPath); err == nil {
-			return Load(configPath)
+			return configPath, true, nil
 		}
 
 		parent := filepath.Dir(current)
 		if parent == current {
-			break
+			return "", false, nil
 		}
 		current = parent
 	}
-	return DefaultConfig(), nil
 }
 
 func (c *Config) GetRuleSeverity(ruleID string, defaultSeverity diagnostic.Severity) *diagnostic.Severity {
@@ -109,10 +177,50 @@ func (c *Config) IsRuleEnabled(ruleID string) bool {
 }
 
 func (c *Config) ShouldExclude(file string) bool {
+	return MatchAny(c.Exclude, file)
+}
+
+// ForFile returns the configuration that applies to a file, with all
+// matching overrides merged in order. The result has no overrides.
+func (c *Config) ForFile(file string) *Config {
+	resolved := &Config{
+		Rules:       make(map[string]string, len(c.Rules)),
+		RuleOptions: make(map[string]map[string]any, len(c.RuleOptions)),
+		Exclude:     c.Exclude,
+		Include:     c.Include,
+		Gitignore:   c.Gitignore,
+		Schema:      c.Schema,
+		GoFacts:     c.GoFacts,
+
+		KnowledgeBases: c.KnowledgeBases,
+		Complexity:     c.Complexity,
+		Naming:         c.Naming,
+	}
+	for k, v := range c.Rules {
+		resolved.Rules[k] = v
+	}
+	mergeRuleOptions(resolved.RuleOptions, c.RuleOptions)
+
+	for _, o := range c.Overrides {
+		if MatchAny(o.Files, file) {
+			resolved.Merge(&Config{
+				Rules:       o.Rules,
+				RuleOptions: o.RuleOptions,
+				Complexity:  o.Complexity,
+				Naming:      o.Naming,
+			})
+		}
+	}
+	return resolved
+}
+
+// MatchAny reports whether a file matches any of the glob patterns,
+// either by its full path or by its basename.
+func MatchAny(patterns []string, file string) bool {
 	// Normalize path separators for cross-platform matching
-	normalizedFile := filepath.ToSlash(file)
+	normalizedFile := filepath.ToSlash(filepath.Clean(file))
 
-	for _, pattern := range c.Exclude {
+	for _, pattern := range patterns {
 		// Use doublestar for proper glob matching with ** support
 		matched, err := doublestar.Match(pattern, normalizedFile)
 		if err == nil && matched {
@@ -128,6 +236,27 @@ func (c *Config) ShouldExclude(file string) bool {
 	return false
 }
 
+// ruleOptions returns the rule options, creating the map if needed.
+func (c *Config) ruleOptions() map[string]map[string]any {
+	if c.RuleOptions == nil {
+		c.RuleOptions = make(map[string]map[string]any)
+	}
+	return c.RuleOptions
+}
+
+// legacyRuleOptions returns the complexity and naming settings as the
+// options of GRL006 and GRL007.
+func (c *Config) legacyRuleOptions() map[string]map[string]any {
+	options := make(map[string]map[string]any)
+	if c.Complexity.MaxConditions > 0 {
+		options["GRL006"] = map[string]any{"max-conditions": c.Complexity.MaxConditions}
+	}
+	if c.Naming.Convention != "" {
+		options["GRL007"] = map[string]any{"convention": c.Naming.Convention}
+	}
+	return options
+}
+
 func (c *Config) Merge(other *Config) {
 	if other == nil {
 		return
@@ -137,6 +266,15 @@ func (c *Config) Merge(other *Config) {
 		c.Rules[k] = v
 	}
 
+	// The complexity and naming settings are the GRL006 and GRL007 options
+	// under older names. They are merged as options, so that a later
+	// config overrides an option set by an earlier one and vice versa;
+	// within one config the options take precedence.
+	mergeRuleOptions(c.ruleOptions(), other.legacyRuleOptions())
+	if len(other.RuleOptions) > 0 {
+		mergeRuleOptions(c.ruleOptions(), other.RuleOptions)
+	}
+
 	if len(other.Exclude) > 0 {
 		c.Exclude = append(c.Exclude, other.Exclude...)
 	}
@@ -145,6 +283,28 @@ func (c *Config) Merge(other *Config) {
 		c.Include = other.Include
 	}
 
+	if other.Gitignore {
+		c.Gitignore = true
+	}
+
+	if other.Schema != "" {
+		c.Schema = other.Schema
+	}
+
+	if other.GoFacts.Package != "" {
+		c.GoFacts.Package = other.GoFacts.Package
+	}
+	if len(other.GoFacts.Facts) > 0 {
+		facts := make(map[string]string, len(c.GoFacts.Facts)+len(other.GoFacts.Facts))
+		for k, v := range c.GoFacts.Facts {
+			facts[k] = v
+		}
+		for k, v := range other.GoFacts.Facts {
+			facts[k] = v
+		}
+		c.GoFacts.Facts = facts
+	}
+
 	if other.Complexity.MaxConditions > 0 {
 		c.Complexity.MaxConditions = other.Complexity.MaxConditions
 	}
@@ -152,4 +312,25 @@ func (c *Config) Merge(other *Config) {
 	if other.Naming.Convention != "" {
 		c.Naming.Convention = other.Naming.Convention
 	}
+
+	if len(other.Overrides) > 0 {
+		c.Overrides = append(c.Overrides, other.Overrides...)
+	}
+
+	// Knowledge bases are replaced by name
+	for _, kb := range other.KnowledgeBases {
+		if kb.Version == "" {
+			kb.Version = DefaultKnowledgeBaseVersion
+		}
+		replaced := false
+		for i := range c.KnowledgeBases {
+			if c.KnowledgeBases[i].Name == kb.Name {
+				c.KnowledgeBases[i] = kb
+				replaced = true
+			}
+		}
+		if !replaced {
+			c.KnowledgeBases = append(c.KnowledgeBases, kb)
+		}
+	}
 }
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/adarshjos/grule-lint/internal/diagnostic"
@@ -114,6 +115,422 @@ func TestShouldExclude(t *testing.T) {
 	}
 }
 
+func TestForFile_Overrides(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, ".grl-lint.yaml")
+	content := []byte(`
+rules:
+  GRL004: warning
+  GRL007: warning
+complexity:
+  max_conditions: 5
+overrides:
+  - files: ["rules/legacy/**"]
+    rules:
+      GRL007: off
+    naming:
+      convention: snake_case
+  - files: ["rules/payments/**"]
+    rules:
+      GRL004: error
+    complexity:
+      max_conditions: 8
+`)
+	if err := os.WriteFile(configPath, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	legacy := cfg.ForFile("rules/legacy/old.grl")
+	if legacy.IsRuleEnabled("GRL007") {
+		t.Error("GRL007 should be disabled for legacy rules")
+	}
+	if legacy.Naming.Convention != "snake_case" {
+		t.Errorf("expected snake_case for legacy rules, got %s", legacy.Naming.Convention)
+	}
+
+	payments := cfg.ForFile("./rules/payments/refund.grl")
+	if sev := payments.GetRuleSeverity("GRL004", diagnostic.SeverityWarning); sev == nil || *sev != diagnostic.SeverityError {
+		t.Errorf("expected GRL004=error for payments, got %v", sev)
+	}
+	if payments.Complexity.MaxConditions != 8 {
+		t.Errorf("expected MaxConditions=8 for payments, got %d", payments.Complexity.MaxConditions)
+	}
+	if !payments.IsRuleEnabled("GRL007") {
+		t.Error("GRL007 should stay enabled for payments")
+	}
+
+	// Resolving must not modify the base config
+	if cfg.Rules["GRL007"] != "warning" || cfg.Rules["GRL004"] != "warning" {
+		t.Errorf("base rules modified: %v", cfg.Rules)
+	}
+}
+
 func ptr(s diagnostic.Severity) *diagnostic.Severity {
 	return &s
 }
+
+func TestLoad_Extends(t *testing.T) {
+	dir := t.TempDir()
+	shared := filepath.Join(dir, "shared")
+	if err := os.MkdirAll(shared, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	base := []byte(`
+extends: [preset:recommended]
+rules:
+  GRL004: error
+complexity:
+  max_conditions: 10
+exclude: ["**/vendor/**"]
+`)
+	if err := os.WriteFile(filepath.Join(shared, "base.grl-lint.yaml"), base, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	child := []byte(`
+extends: [./shared/base.grl-lint.yaml]
+rules:
+  GRL002: off
+exclude: ["**/generated/**"]
+`)
+	configPath := filepath.Join(dir, ".grl-lint.yaml")
+	if err := os.WriteFile(configPath, child, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	expected := map[string]string{
+		"GRL002": "off",     // child
+		"GRL003": "off",     // recommended
+		"GRL004": "error",   // base
+		"GRL005": "error",   // all, via recommended
+		"GRL013": "warning", // all, via recommended
+	}
+	for id, severity := range expected {
+		if cfg.Rules[id] != severity {
+			t.Errorf("expected %s=%s, got %q", id, severity, cfg.Rules[id])
+		}
+	}
+	if cfg.Complexity.MaxConditions != 10 {
+		t.Errorf("expected MaxConditions=10 from base, got %d", cfg.Complexity.MaxConditions)
+	}
+	if cfg.Naming.Convention != "PascalCase" {
+		t.Errorf("expected default naming convention, got %s", cfg.Naming.Convention)
+	}
+	if len(cfg.Exclude) != 2 || cfg.Exclude[0] != "**/vendor/**" || cfg.Exclude[1] != "**/generated/**" {
+		t.Errorf("expected base then child excludes, got %v", cfg.Exclude)
+	}
+}
+
+func TestLoad_ExtendsCycle(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.yaml")
+	b := filepath.Join(dir, "b.yaml")
+	if err := os.WriteFile(a, []byte("extends: [b.yaml]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(b, []byte("extends: [a.yaml]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := Load(a)
+	if err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Errorf("expected extends cycle error, got %v", err)
+	}
+}
+
+func TestLoad_UnknownPreset(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".grl-lint.yaml")
+	if err := os.WriteFile(configPath, []byte("extends: [preset:lenient]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Load(configPath); err == nil {
+		t.Error("expected error for unknown preset")
+	}
+}
+
+func TestPresets(t *testing.T) {
+	for _, name := range Presets() {
+		ld := &loader{}
+		if _, err := ld.loadPreset(name); err != nil {
+			t.Errorf("preset %s: %v", name, err)
+		}
+	}
+	if len(Presets()) != 3 {
+		t.Errorf("expected 3 presets, got %v", Presets())
+	}
+}
+
+func TestValidateFile(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".grl-lint.yaml")
+	content := []byte(`rules:
+  GRL002: warnng
+  GRL099: error
+naming:
+  convention: pascal
+setings:
+  foo: bar
+`)
+	if err := os.WriteFile(configPath, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	problems, err := ValidateFile(configPath)
+	if err != nil {
+		t.Fatalf("ValidateFile failed: %v", err)
+	}
+
+	expected := []struct {
+		line    int
+		message string
+	}{
+		{2, `invalid severity "warnng"`},
+		{3, `unknown rule ID "GRL099"`},
+		{5, `invalid naming convention "pascal"`},
+		{6, `unknown key "setings"`},
+	}
+	if len(problems) != len(expected) {
+		t.Fatalf("expected %d problems, got %d: %v", len(expected), len(problems), problems)
+	}
+	for i, e := range expected {
+		if problems[i].Line != e.line || !strings.Contains(problems[i].Message, e.message) {
+			t.Errorf("problem %d: expected line %d %q, got %s", i, e.line, e.message, problems[i])
+		}
+	}
+
+	if _, err := Load(configPath); err == nil {
+		t.Error("expected Load to reject an invalid config")
+	}
+}
+
+func TestLoad_UnknownKeysWarn(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".grl-lint.yaml")
+	content := []byte(`settings:
+  high-complexity:
+    max-conditions: 5
+naming:
+  convention: camelCase
+`)
+	if err := os.WriteFile(configPath, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Linting ignores unknown keys with a warning
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(cfg.Warnings) != 1 || !strings.Contains(cfg.Warnings[0].Message, "settings is deprecated") {
+		t.Errorf("expected a deprecation warning for settings, got %v", cfg.Warnings)
+	}
+	if cfg.Naming.Convention != "camelCase" {
+		t.Errorf("expected the other settings to apply, got %q", cfg.Naming.Convention)
+	}
+
+	// Validation still reports them
+	problems, err := ValidateFile(configPath)
+	if err != nil {
+		t.Fatalf("ValidateFile failed: %v", err)
+	}
+	if len(problems) != 1 || problems[0].Line != 1 {
+		t.Errorf("expected the settings key as a problem, got %v", problems)
+	}
+}
+
+func TestLoad_RuleOptions(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".grl-lint.yaml")
+	content := []byte(`
+rules:
+  GRL002: info
+  GRL006:
+    severity: error
+    options:
+      max-conditions: 8
+overrides:
+  - files: ["legacy/**"]
+    rules:
+      GRL006:
+        options:
+          max-conditions: 12
+`)
+	if err := os.WriteFile(configPath, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if cfg.Rules["GRL002"] != "info" || cfg.Rules["GRL006"] != "error" {
+		t.Errorf("unexpected severities: %v", cfg.Rules)
+	}
+	if got := cfg.RuleOptions["GRL006"]["max-conditions"]; got != 8 {
+		t.Errorf("expected max-conditions=8, got %v", got)
+	}
+
+	legacy := cfg.ForFile("legacy/old.grl")
+	if got := legacy.RuleOptions["GRL006"]["max-conditions"]; got != 12 {
+		t.Errorf("expected max-conditions=12 for legacy files, got %v", got)
+	}
+	if legacy.Rules["GRL006"] != "error" {
+		t.Errorf("override without severity should keep GRL006=error, got %q", legacy.Rules["GRL006"])
+	}
+	if got := cfg.RuleOptions["GRL006"]["max-conditions"]; got != 8 {
+		t.Errorf("base options modified: %v", got)
+	}
+}
+
+func TestForFile_LegacySettingsOverrideOptions(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".grl-lint.yaml")
+	content := []byte(`
+rules:
+  GRL006:
+    options:
+      max-conditions: 3
+naming:
+  convention: camelCase
+overrides:
+  - files: ["legacy/**"]
+    complexity:
+      max_conditions: 8
+  - files: ["snake/**"]
+    rules:
+      GRL007:
+        options:
+          convention: snake_case
+`)
+	if err := os.WriteFile(configPath, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	// The later, more specific setting wins whichever key it uses
+	if got := cfg.ForFile("legacy/old.grl").RuleOptions["GRL006"]["max-conditions"]; got != 8 {
+		t.Errorf("expected the override's max_conditions=8, got %v", got)
+	}
+	if got := cfg.ForFile("main.grl").RuleOptions["GRL006"]["max-conditions"]; got != 3 {
+		t.Errorf("expected max-conditions=3 outside the override, got %v", got)
+	}
+	if got := cfg.ForFile("snake/a.grl").RuleOptions["GRL007"]["convention"]; got != "snake_case" {
+		t.Errorf("expected the override's convention=snake_case, got %v", got)
+	}
+}
+
+func TestValidateFile_RuleOptions(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".grl-lint.yaml")
+	content := []byte(`rules:
+  GRL006:
+    severity: error
+    options:
+      max-conditons: 8
+  GRL007:
+    options:
+      convention: pascal
+  GRL002:
+    options:
+      foo: bar
+  GRL004:
+    level: error
+`)
+	if err := os.WriteFile(configPath, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	problems, err := ValidateFile(configPath)
+	if err != nil {
+		t.Fatalf("ValidateFile failed: %v", err)
+	}
+
+	expected := []struct {
+		line    int
+		message string
+	}{
+		{5, `did you mean "max-conditions"?`},
+		{8, `option convention must be one of`},
+		{11, `GRL002 does not accept options`},
+		{13, `unknown key "level" in GRL004`},
+	}
+	if len(problems) != len(expected) {
+		t.Fatalf("expected %d problems, got %d: %v", len(expected), len(problems), problems)
+	}
+	for i, e := range expected {
+		if problems[i].Line != e.line || !strings.Contains(problems[i].Message, e.message) {
+			t.Errorf("problem %d: expected line %d %q, got %s", i, e.line, e.message, problems[i])
+		}
+	}
+}
+
+func TestLoad_SchemaRelativeToConfig(t *testing.T) {
+	dir := t.TempDir()
+	shared := filepath.Join(dir, "shared")
+	if err := os.MkdirAll(shared, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(shared, "base.yaml"), []byte("schema: facts.yaml\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	configPath := filepath.Join(dir, ".grl-lint.yaml")
+	if err := os.WriteFile(configPath, []byte("extends: [shared/base.yaml]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if want := filepath.Join(shared, "facts.yaml"); cfg.Schema != want {
+		t.Errorf("expected schema %s, got %s", want, cfg.Schema)
+	}
+}
+
+func TestValidateFile_KnowledgeBases(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".grl-lint.yaml")
+	content := []byte(`knowledge_bases:
+  - name: Pricing
+    files: ["rules/pricing/*.grl"]
+  - name: Pricing
+    files: ["rules/shared/*.grl"]
+  - version: 1.0.0
+    files: ["rules/*.grl"]
+`)
+	if err := os.WriteFile(configPath, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	problems, err := ValidateFile(configPath)
+	if err != nil {
+		t.Fatalf("ValidateFile failed: %v", err)
+	}
+
+	expected := []struct {
+		line    int
+		message string
+	}{
+		{4, `duplicate knowledge base "Pricing"`},
+		{6, "knowledge base is missing name"},
+	}
+	if len(problems) != len(expected) {
+		t.Fatalf("expected %d problems, got %d: %v", len(expected), len(problems), problems)
+	}
+	for i, e := range expected {
+		if problems[i].Line != e.line || !strings.Contains(problems[i].Message, e.message) {
+			t.Errorf("problem %d: expected line %d %q, got %s", i, e.line, e.message, problems[i])
+		}
+	}
+}
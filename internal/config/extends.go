@@ -0,0 +1,141 @@
+package config
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// presetPrefix marks an extends entry that refers to a built-in preset.
+const presetPrefix = "preset:"
+
+//go:embed presets/*.yaml
+var presetFS embed.FS
+
+// Presets returns the names of the built-in presets.
+func Presets() []string {
+	entries, err := presetFS.ReadDir("presets")
+	if err != nil {
+		return nil
+	}
+
+	var names []string
+	for _, e := range entries {
+		names = append(names, strings.TrimSuffix(e.Name(), ".yaml"))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// loader resolves extends chains while detecting cycles.
+type loader struct {
+	// stack holds the configs currently being loaded, outermost first.
+	stack []string
+
+	// strict makes unknown keys errors instead of warnings.
+	strict bool
+
+	// warnings collects the unknown keys found when not strict.
+	warnings []Problem
+}
+
+// loadFile reads a config file and everything it extends. The result only
+// contains values set in the chain; defaults are applied by the caller.
+func (ld *loader) loadFile(path string) (*Config, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("resolving config path %s: %w", path, err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading config file %s: %w", path, err)
+	}
+
+	return ld.load(absPath, path, filepath.Dir(absPath), data)
+}
+
+// loadPreset reads a built-in preset and everything it extends.
+func (ld *loader) loadPreset(name string) (*Config, error) {
+	data, err := presetFS.ReadFile("presets/" + name + ".yaml")
+	if err != nil {
+		return nil, fmt.Errorf("unknown preset %q (valid: %s)", name, strings.Join(Presets(), ", "))
+	}
+
+	return ld.load(presetPrefix+name, presetPrefix+name, "", data)
+}
+
+// load validates and parses config data identified by key and displayed as
+// name. Entries in its extends list are merged first, in order, followed by
+// the config's own values. Relative paths are resolved against dir; presets
+// have no dir and may only extend other presets.
+func (ld *loader) load(key, name, dir string, data []byte) (*Config, error) {
+	for i, k := range ld.stack {
+		if k == key {
+			chain := append(append([]string{}, ld.stack[i:]...), key)
+			return nil, fmt.Errorf("config extends cycle: %s", strings.Join(chain, " -> "))
+		}
+	}
+	ld.stack = append(ld.stack, key)
+	defer func() { ld.stack = ld.stack[:len(ld.stack)-1] }()
+
+	problems, err := validate(name, data)
+	if err != nil {
+		return nil, err
+	}
+	var errs []Problem
+	for _, p := range problems {
+		if p.UnknownKey && !ld.strict {
+			ld.warnings = append(ld.warnings, p)
+			continue
+		}
+		errs = append(errs, p)
+	}
+	if len(errs) > 0 {
+		return nil, &ValidationError{Problems: errs}
+	}
+
+	own := &Config{}
+	if err := yaml.Unmarshal(data, own); err != nil {
+		return nil, fmt.Errorf("parsing config file %s: %w", name, err)
+	}
+
+	resolved := &Config{Rules: make(map[string]string)}
+	for _, ext := range own.Extends {
+		var parent *Config
+		var err error
+
+		switch {
+		case strings.HasPrefix(ext, presetPrefix):
+			parent, err = ld.loadPreset(strings.TrimPrefix(ext, presetPrefix))
+		case dir == "":
+			err = fmt.Errorf("preset %s cannot extend file %s", name, ext)
+		case filepath.IsAbs(ext):
+			parent, err = ld.loadFile(ext)
+		default:
+			parent, err = ld.loadFile(filepath.Join(dir, ext))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("extending %s from %s: %w", ext, name, err)
+		}
+
+		resolved.Merge(parent)
+	}
+
+	// Schema paths are relative to the config that declares them
+	if own.Schema != "" && dir != "" && !filepath.IsAbs(own.Schema) {
+		own.Schema = filepath.Join(dir, own.Schema)
+	}
+	if own.GoFacts.Package != "" && dir != "" && !filepath.IsAbs(own.GoFacts.Package) {
+		own.GoFacts.Package = filepath.Join(dir, own.GoFacts.Package)
+	}
+
+	own.Extends = nil
+	resolved.Merge(own)
+	return resolved, nil
+}
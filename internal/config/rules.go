@@ -0,0 +1,101 @@
+package config
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ruleEntry is a rule setting in the rules block: either a severity, or a
+// mapping with a severity and rule options:
+//
+//	GRL003: off
+//	GRL006:
+//	  severity: error
+//	  options:
+//	    max-conditions: 8
+type ruleEntry struct {
+	Severity string
+	Options  map[string]any
+}
+
+func (e *ruleEntry) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		return node.Decode(&e.Severity)
+	}
+
+	var full struct {
+		Severity string         `yaml:"severity"`
+		Options  map[string]any `yaml:"options"`
+	}
+	if err := node.Decode(&full); err != nil {
+		return fmt.Errorf("line %d: rule settings must be a severity or a mapping with severity and options: %w", node.Line, err)
+	}
+	e.Severity, e.Options = full.Severity, full.Options
+	return nil
+}
+
+// ruleEntries is the rules block of a config or override.
+type ruleEntries map[string]ruleEntry
+
+// split returns the severities and options set by the entries. Rules that
+// only set options have no severity.
+func (r ruleEntries) split() (map[string]string, map[string]map[string]any) {
+	severities := make(map[string]string, len(r))
+	var options map[string]map[string]any
+	for id, entry := range r {
+		if entry.Severity != "" {
+			severities[id] = entry.Severity
+		}
+		if len(entry.Options) > 0 {
+			if options == nil {
+				options = make(map[string]map[string]any)
+			}
+			options[id] = entry.Options
+		}
+	}
+	return severities, options
+}
+
+func (c *Config) UnmarshalYAML(node *yaml.Node) error {
+	type plain Config
+	raw := struct {
+		*plain `yaml:",inline"`
+		Rules  ruleEntries `yaml:"rules"`
+	}{plain: (*plain)(c)}
+
+	if err := node.Decode(&raw); err != nil {
+		return err
+	}
+	c.Rules, c.RuleOptions = raw.Rules.split()
+	return nil
+}
+
+func (o *Override) UnmarshalYAML(node *yaml.Node) error {
+	type plain Override
+	raw := struct {
+		*plain `yaml:",inline"`
+		Rules  ruleEntries `yaml:"rules"`
+	}{plain: (*plain)(o)}
+
+	if err := node.Decode(&raw); err != nil {
+		return err
+	}
+	o.Rules, o.RuleOptions = raw.Rules.split()
+	return nil
+}
+
+// mergeRuleOptions copies options from src into dst, option by option, so
+// later configs only replace the options they set.
+func mergeRuleOptions(dst, src map[string]map[string]any) {
+	for id, options := range src {
+		merged := make(map[string]any, len(dst[id])+len(options))
+		for name, value := range dst[id] {
+			merged[name] = value
+		}
+		for name, value := range options {
+			merged[name] = value
+		}
+		dst[id] = merged
+	}
+}
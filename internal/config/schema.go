@@ -0,0 +1,38 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/adarshjos/grule-lint/internal/schema"
+)
+
+// LoadSchema loads the fact schema described by the config, combining the
+// schema file with facts derived from Go types. Facts in the schema file
+// take precedence. It returns nil if neither is configured.
+func (c *Config) LoadSchema() (*schema.Schema, error) {
+	var s *schema.Schema
+	if c.Schema != "" {
+		loaded, err := schema.Load(c.Schema)
+		if err != nil {
+			return nil, err
+		}
+		s = loaded
+	}
+
+	if len(c.GoFacts.Facts) > 0 {
+		if c.GoFacts.Package == "" {
+			return nil, fmt.Errorf("go_facts.facts requires go_facts.package")
+		}
+		derived, err := schema.LoadGoPackage(c.GoFacts.Package, c.GoFacts.Facts)
+		if err != nil {
+			return nil, err
+		}
+		if s == nil {
+			s = derived
+		} else {
+			s.Merge(derived)
+		}
+	}
+
+	return s, nil
+}
@@ -0,0 +1,353 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/adarshjos/grule-lint/internal/rules"
+	"github.com/adarshjos/grule-lint/internal/suggest"
+)
+
+// validSeverities lists the values accepted for a rule in the rules block.
+var validSeverities = []string{"error", "warning", "warn", "info", "hint", "off", "disabled"}
+
+// validConventions lists the values accepted for naming.convention.
+var validConventions = []string{
+	string(rules.ConventionPascalCase),
+	string(rules.ConventionCamelCase),
+	string(rules.ConventionSnakeCase),
+	string(rules.ConventionKebabCase),
+}
+
+// Problem is an invalid setting found in a config file.
+type Problem struct {
+	File    string
+	Line    int
+	Column  int
+	Message string
+
+	// UnknownKey is set for keys grule-lint does not read. Linting only
+	// warns about them, while config validate reports them as errors.
+	UnknownKey bool
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s", p.File, p.Line, p.Column, p.Message)
+}
+
+// ValidationError reports all problems found in a config file.
+type ValidationError struct {
+	Problems []Problem
+}
+
+func (e *ValidationError) Error() string {
+	lines := make([]string, 0, len(e.Problems)+1)
+	lines = append(lines, fmt.Sprintf("invalid configuration (%d problem(s)):", len(e.Problems)))
+	for _, p := range e.Problems {
+		lines = append(lines, "  "+p.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ValidateFile checks a config file and the configs it extends. Invalid
+// settings, including unknown keys, are returned as problems; other
+// failures, such as unreadable files or YAML syntax errors, are returned as
+// an error.
+func ValidateFile(path string) ([]Problem, error) {
+	_, err := load(&loader{strict: true}, path)
+
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return validationErr.Problems, nil
+	}
+	return nil, err
+}
+
+// validator checks a config document against the known keys and values.
+type validator struct {
+	file     string
+	rules    map[string]rules.Rule
+	problems []Problem
+}
+
+// validate checks raw config data and returns the problems found, sorted
+// by position. YAML syntax errors are returned as an error.
+func validate(file string, data []byte) ([]Problem, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parsing config file %s: %w", file, err)
+	}
+
+	v := &validator{file: file, rules: rules.DefaultRegistry().AllRules()}
+
+	// An empty document is a valid, empty config
+	if len(doc.Content) > 0 {
+		v.checkTopLevel(doc.Content[0])
+	}
+
+	sort.SliceStable(v.problems, func(i, j int) bool {
+		a, b := v.problems[i], v.problems[j]
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return v.problems, nil
+}
+
+func (v *validator) report(node *yaml.Node, format string, args ...any) {
+	v.problems = append(v.problems, Problem{
+		File:    v.file,
+		Line:    node.Line,
+		Column:  node.Column,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// mapping iterates over the key/value pairs of a mapping node, reporting
+// keys that are not in allowed.
+func (v *validator) mapping(node *yaml.Node, context string, allowed []string, each func(key, value *yaml.Node)) {
+	if node.Kind != yaml.MappingNode {
+		v.report(node, "%s must be a mapping", context)
+		return
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if allowed != nil && !contains(allowed, key.Value) {
+			hint := didYouMean(key.Value, allowed)
+			if context == "config" && key.Value == "settings" {
+				// Shown in earlier versions of the README, but never read
+				hint = "; settings is deprecated and ignored, use naming and complexity instead"
+			}
+			v.report(key, "unknown key %q in %s%s", key.Value, context, hint)
+			v.problems[len(v.problems)-1].UnknownKey = true
+			continue
+		}
+		each(key, value)
+	}
+}
+
+func (v *validator) checkTopLevel(node *yaml.Node) {
+	allowed := []string{"extends", "rules", "exclude", "include", "gitignore", "schema", "go_facts", "complexity", "naming", "overrides", "knowledge_bases"}
+
+	v.mapping(node, "config", allowed, func(key, value *yaml.Node) {
+		switch key.Value {
+		case "extends", "exclude", "include":
+			v.checkStrings(value, key.Value)
+		case "gitignore":
+			if value.Kind != yaml.ScalarNode || (value.Value != "true" && value.Value != "false") {
+				v.report(value, "gitignore must be true or false, got %q", value.Value)
+			}
+		case "schema":
+			if value.Kind != yaml.ScalarNode || value.Value == "" {
+				v.report(value, "schema must be a file path")
+			}
+		case "go_facts":
+			v.checkGoFacts(value)
+		case "rules":
+			v.checkRules(value)
+		case "complexity":
+			v.checkComplexity(value)
+		case "naming":
+			v.checkNaming(value)
+		case "overrides":
+			v.checkOverrides(value)
+		case "knowledge_bases":
+			v.checkKnowledgeBases(value)
+		}
+	})
+}
+
+func (v *validator) checkKnowledgeBases(node *yaml.Node) {
+	if node.Kind != yaml.SequenceNode {
+		v.report(node, "knowledge_bases must be a list")
+		return
+	}
+
+	names := make(map[string]bool)
+	for _, item := range node.Content {
+		hasName, hasFiles := false, false
+		v.mapping(item, "knowledge base", []string{"name", "version", "files"}, func(key, value *yaml.Node) {
+			switch key.Value {
+			case "name", "version":
+				if value.Kind != yaml.ScalarNode || value.Value == "" {
+					v.report(value, "knowledge base %s must be a string", key.Value)
+					return
+				}
+				if key.Value == "name" {
+					hasName = true
+					if names[value.Value] {
+						v.report(value, "duplicate knowledge base %q", value.Value)
+					}
+					names[value.Value] = true
+				}
+			case "files":
+				hasFiles = true
+				v.checkStrings(value, "files")
+			}
+		})
+		if item.Kind == yaml.MappingNode {
+			if !hasName {
+				v.report(item, "knowledge base is missing name")
+			}
+			if !hasFiles {
+				v.report(item, "knowledge base is missing files")
+			}
+		}
+	}
+}
+
+func (v *validator) checkGoFacts(node *yaml.Node) {
+	v.mapping(node, "go_facts", []string{"package", "facts"}, func(key, value *yaml.Node) {
+		switch key.Value {
+		case "package":
+			if value.Kind != yaml.ScalarNode || value.Value == "" {
+				v.report(value, "go_facts.package must be a directory path")
+			}
+		case "facts":
+			v.mapping(value, "go_facts.facts", nil, func(name, typeName *yaml.Node) {
+				if typeName.Kind != yaml.ScalarNode || typeName.Value == "" {
+					v.report(typeName, "go_facts.facts.%s must be a type name", name.Value)
+				}
+			})
+		}
+	})
+}
+
+func (v *validator) checkStrings(node *yaml.Node, context string) {
+	if node.Kind != yaml.SequenceNode {
+		v.report(node, "%s must be a list of strings", context)
+		return
+	}
+	for _, item := range node.Content {
+		if item.Kind != yaml.ScalarNode {
+			v.report(item, "%s entries must be strings", context)
+		}
+	}
+}
+
+func (v *validator) checkRules(node *yaml.Node) {
+	v.mapping(node, "rules", nil, func(key, value *yaml.Node) {
+		rule, known := v.rules[key.Value]
+		if !known {
+			v.report(key, "unknown rule ID %q", key.Value)
+		}
+
+		if value.Kind != yaml.MappingNode {
+			v.checkSeverity(key.Value, value)
+			return
+		}
+		v.mapping(value, key.Value, []string{"severity", "options"}, func(k, val *yaml.Node) {
+			switch k.Value {
+			case "severity":
+				v.checkSeverity(key.Value, val)
+			case "options":
+				if known {
+					v.checkOptions(rule, val)
+				}
+			}
+		})
+	})
+}
+
+func (v *validator) checkSeverity(ruleID string, node *yaml.Node) {
+	if node.Kind != yaml.ScalarNode || !contains(validSeverities, node.Value) {
+		v.report(node, "invalid severity %q for %s (valid: %s)%s",
+			node.Value, ruleID, strings.Join(validSeverities, ", "), didYouMean(node.Value, validSeverities))
+	}
+}
+
+func (v *validator) checkOptions(rule rules.Rule, node *yaml.Node) {
+	configurable, ok := rule.(rules.ConfigurableRule)
+	if !ok {
+		v.report(node, "%s does not accept options", rule.ID())
+		return
+	}
+
+	var names []string
+	for _, o := range configurable.Options() {
+		names = append(names, o.Name)
+	}
+
+	v.mapping(node, rule.ID()+" options", names, func(key, value *yaml.Node) {
+		opt, _ := rules.FindOption(configurable, key.Value)
+
+		var raw any
+		if err := value.Decode(&raw); err != nil {
+			v.report(value, "invalid value for option %s: %v", key.Value, err)
+			return
+		}
+		if _, err := opt.Check(raw); err != nil {
+			v.report(value, "%v", err)
+		}
+	})
+}
+
+func (v *validator) checkComplexity(node *yaml.Node) {
+	v.mapping(node, "complexity", []string{"max_conditions"}, func(key, value *yaml.Node) {
+		n, err := strconv.Atoi(value.Value)
+		if value.Kind != yaml.ScalarNode || err != nil || n < 1 {
+			v.report(value, "max_conditions must be a positive integer, got %q", value.Value)
+		}
+	})
+}
+
+func (v *validator) checkNaming(node *yaml.Node) {
+	v.mapping(node, "naming", []string{"convention"}, func(key, value *yaml.Node) {
+		if value.Kind != yaml.ScalarNode || !contains(validConventions, value.Value) {
+			v.report(value, "invalid naming convention %q (valid: %s)%s",
+				value.Value, strings.Join(validConventions, ", "), didYouMean(value.Value, validConventions))
+		}
+	})
+}
+
+func (v *validator) checkOverrides(node *yaml.Node) {
+	if node.Kind != yaml.SequenceNode {
+		v.report(node, "overrides must be a list")
+		return
+	}
+
+	allowed := []string{"files", "rules", "complexity", "naming"}
+	for _, item := range node.Content {
+		hasFiles := false
+		v.mapping(item, "override", allowed, func(key, value *yaml.Node) {
+			switch key.Value {
+			case "files":
+				hasFiles = true
+				v.checkStrings(value, "files")
+			case "rules":
+				v.checkRules(value)
+			case "complexity":
+				v.checkComplexity(value)
+			case "naming":
+				v.checkNaming(value)
+			}
+		})
+		if item.Kind == yaml.MappingNode && !hasFiles {
+			v.report(item, "override is missing files")
+		}
+	}
+}
+
+// didYouMean returns a "did you mean" hint for a misspelled value.
+func didYouMean(value string, candidates []string) string {
+	if best, ok := suggest.Closest(value, candidates); ok {
+		return fmt.Sprintf("; did you mean %q?", best)
+	}
+	return ""
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
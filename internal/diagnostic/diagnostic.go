@@ -47,6 +47,11 @@ func (p Position) String() string {
 	return fmt.Sprintf("%d:%d", p.Line, p.Column)
 }
 
+// Before reports whether p comes before other in the file.
+func (p Position) Before(other Position) bool {
+	return p.Line < other.Line || (p.Line == other.Line && p.Column < other.Column)
+}
+
 // Range represents a span of text in a source file.
 type Range struct {
 	Start Position // Start is the beginning of the range (inclusive)
@@ -80,6 +85,10 @@ type Diagnostic struct {
 // for aggregation, filtering, and sorting.
 type DiagnosticSet struct {
 	diagnostics []Diagnostic
+
+	// files lists the files checked to produce the set, including files
+	// without diagnostics.
+	files map[string]bool
 }
 
 // NewDiagnosticSet creates a new empty DiagnosticSet.
@@ -89,6 +98,27 @@ func NewDiagnosticSet() *DiagnosticSet {
 	}
 }
 
+// AddFiles records files that were checked, so reporters can list files
+// without diagnostics. Files already recorded are ignored.
+func (ds *DiagnosticSet) AddFiles(files ...string) {
+	if ds.files == nil {
+		ds.files = make(map[string]bool, len(files))
+	}
+	for _, file := range files {
+		ds.files[file] = true
+	}
+}
+
+// Files returns the checked files in sorted order.
+func (ds *DiagnosticSet) Files() []string {
+	files := make([]string, 0, len(ds.files))
+	for file := range ds.files {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	return files
+}
+
 // Add appends a single diagnostic to the set.
 func (ds *DiagnosticSet) Add(d Diagnostic) {
 	ds.diagnostics = append(ds.diagnostics, d)
@@ -104,19 +134,26 @@ func (ds *DiagnosticSet) All() []Diagnostic {
 	return ds.diagnostics
 }
 
-// Sorted returns a copy of diagnostics sorted by file, line, then column.
+// Sorted returns a copy of diagnostics sorted by file, line, column, rule
+// ID, then message. Diagnostics equal in all of these keep their order.
 func (ds *DiagnosticSet) Sorted() []Diagnostic {
 	sorted := make([]Diagnostic, len(ds.diagnostics))
 	copy(sorted, ds.diagnostics)
 
-	sort.Slice(sorted, func(i, j int) bool {
+	sort.SliceStable(sorted, func(i, j int) bool {
 		if sorted[i].File != sorted[j].File {
 			return sorted[i].File < sorted[j].File
 		}
 		if sorted[i].Range.Start.Line != sorted[j].Range.Start.Line {
 			return sorted[i].Range.Start.Line < sorted[j].Range.Start.Line
 		}
-		return sorted[i].Range.Start.Column < sorted[j].Range.Start.Column
+		if sorted[i].Range.Start.Column != sorted[j].Range.Start.Column {
+			return sorted[i].Range.Start.Column < sorted[j].Range.Start.Column
+		}
+		if sorted[i].RuleID != sorted[j].RuleID {
+			return sorted[i].RuleID < sorted[j].RuleID
+		}
+		return sorted[i].Message < sorted[j].Message
 	})
 
 	return sorted
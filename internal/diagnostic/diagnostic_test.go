@@ -1,6 +1,9 @@
 package diagnostic
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestSeverityString(t *testing.T) {
 	tests := []struct {
@@ -57,6 +60,24 @@ func TestDiagnosticSetSorted(t *testing.T) {
 	}
 }
 
+func TestDiagnosticSetSortedTieBreakers(t *testing.T) {
+	pos := Range{Start: Position{Line: 3, Column: 5}}
+	ds := NewDiagnosticSet()
+	ds.Add(Diagnostic{File: "a.grl", Range: pos, RuleID: "GRL009", Message: "b"})
+	ds.Add(Diagnostic{File: "a.grl", Range: pos, RuleID: "GRL004", Message: "z"})
+	ds.Add(Diagnostic{File: "a.grl", Range: pos, RuleID: "GRL009", Message: "a"})
+	ds.Add(Diagnostic{File: "a.grl", Range: pos, RuleID: "GRL009", Message: "a", RuleName: "second"})
+
+	var got []string
+	for _, d := range ds.Sorted() {
+		got = append(got, d.RuleID+" "+d.Message+" "+d.RuleName)
+	}
+	want := []string{"GRL004 z ", "GRL009 a ", "GRL009 a second", "GRL009 b "}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
 func TestFilterBySeverity(t *testing.T) {
 	ds := NewDiagnosticSet()
 	ds.Add(Diagnostic{Severity: SeverityError})
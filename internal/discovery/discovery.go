@@ -0,0 +1,273 @@
+// Package discovery finds the GRL files to lint from command-line paths.
+package discovery
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/adarshjos/grule-lint/internal/config"
+)
+
+// IgnoreFileName is the gitignore-style file listing paths to skip.
+const IgnoreFileName = ".grl-lintignore"
+
+// DefaultInclude matches the files linted when no include patterns are set.
+var DefaultInclude = []string{"**/*.grl"}
+
+// Options controls which files are discovered.
+type Options struct {
+	// Include lists glob patterns files found in directories must match.
+	// Defaults to DefaultInclude.
+	Include []string
+
+	// Exclude lists glob patterns of files to skip.
+	Exclude []string
+
+	// Root is the directory whose ignore files, and those of its
+	// subdirectories, apply to the paths below them. Defaults to the
+	// current directory.
+	Root string
+
+	// Gitignore also honors .gitignore files.
+	Gitignore bool
+}
+
+// Finder discovers GRL files, applying include and exclude patterns and
+// ignore files.
+type Finder struct {
+	opts        Options
+	ignoreNames []string
+
+	// ignores caches the parsed ignore files per absolute directory.
+	ignores map[string][]*ignoreFile
+}
+
+// New creates a new Finder.
+func New(opts Options) *Finder {
+	if len(opts.Include) == 0 {
+		opts.Include = DefaultInclude
+	}
+	if opts.Root == "" {
+		opts.Root = "."
+	}
+
+	names := []string{IgnoreFileName}
+	if opts.Gitignore {
+		names = append(names, ".gitignore")
+	}
+
+	return &Finder{
+		opts:        opts,
+		ignoreNames: names,
+		ignores:     make(map[string][]*ignoreFile),
+	}
+}
+
+// NewFromConfig creates a Finder using the include and exclude patterns and
+// ignore settings of a config.
+func NewFromConfig(cfg *config.Config) *Finder {
+	return New(Options{
+		Include:   cfg.Include,
+		Exclude:   cfg.Exclude,
+		Gitignore: cfg.Gitignore,
+	})
+}
+
+// Find returns the files to lint for the given paths. Directories are walked
+// recursively and only yield files matching the include patterns; files
+// passed explicitly are linted regardless of include patterns. Excluded and
+// ignored files are skipped in both cases, as are files passed explicitly
+// inside an ignored directory.
+//
+// Ignore files apply to paths below their directory. They are read from
+// Root and its subdirectories, or from walked directories outside Root.
+func (f *Finder) Find(paths []string) ([]string, error) {
+	root, err := filepath.Abs(f.opts.Root)
+	if err != nil {
+		return nil, fmt.Errorf("resolving root directory %s: %w", f.opts.Root, err)
+	}
+
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("accessing path %s: %w", path, err)
+		}
+
+		if !info.IsDir() {
+			skip, err := f.skipFile(path, root, filepath.Dir(path))
+			if err != nil {
+				return nil, err
+			}
+			if !skip {
+				files = append(files, path)
+			}
+			continue
+		}
+
+		found, err := f.walk(path, root)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, found...)
+	}
+
+	return files, nil
+}
+
+// walk finds the included files below a directory.
+func (f *Finder) walk(dir, root string) ([]string, error) {
+	var files []string
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return fmt.Errorf("walking directory: %w", err)
+		}
+
+		if d.IsDir() {
+			if path == dir {
+				return nil
+			}
+			skip, err := f.skip(path, true, root, dir)
+			if err != nil {
+				return err
+			}
+			if skip {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if !config.MatchAny(f.opts.Include, path) {
+			return nil
+		}
+		skip, err := f.skip(path, false, root, dir)
+		if err != nil {
+			return err
+		}
+		if !skip {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scanning directory %s: %w", dir, err)
+	}
+
+	return files, nil
+}
+
+// skipFile reports whether a file passed explicitly is skipped. As when
+// walking, a file is skipped if one of its directories below the ignore
+// file root is ignored, e.g. by a "build/" pattern.
+func (f *Finder) skipFile(path, root, base string) (bool, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return false, fmt.Errorf("resolving path %s: %w", path, err)
+	}
+	stop, err := stopDir(absPath, root, base)
+	if err != nil {
+		return false, err
+	}
+
+	var dirs []string
+	for dir := filepath.Dir(absPath); isBelow(dir, stop); dir = filepath.Dir(dir) {
+		dirs = append(dirs, dir)
+	}
+	for i := len(dirs) - 1; i >= 0; i-- {
+		skip, err := f.skip(dirs[i], true, root, base)
+		if err != nil || skip {
+			return skip, err
+		}
+	}
+	return f.skip(path, false, root, base)
+}
+
+// stopDir returns the outermost directory whose ignore files apply to a
+// path: root if the path is below it, or base otherwise.
+func stopDir(absPath, root, base string) (string, error) {
+	if isBelow(absPath, root) {
+		return root, nil
+	}
+	stop, err := filepath.Abs(base)
+	if err != nil {
+		return "", fmt.Errorf("resolving path %s: %w", base, err)
+	}
+	return stop, nil
+}
+
+// skip reports whether a path is excluded by patterns or ignore files.
+// Ignore files closer to the path take precedence. Ignore files are
+// collected up to root if the path is below it, or up to base otherwise.
+func (f *Finder) skip(path string, isDir bool, root, base string) (bool, error) {
+	if !isDir && config.MatchAny(f.opts.Exclude, path) {
+		return true, nil
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return false, fmt.Errorf("resolving path %s: %w", path, err)
+	}
+	stop, err := stopDir(absPath, root, base)
+	if err != nil {
+		return false, err
+	}
+
+	// Collect directories from the path's parent up to stop, then apply
+	// their ignore files outermost first
+	var dirs []string
+	for dir := filepath.Dir(absPath); ; dir = filepath.Dir(dir) {
+		dirs = append(dirs, dir)
+		if dir == stop || filepath.Dir(dir) == dir {
+			break
+		}
+	}
+
+	ignored := false
+	for i := len(dirs) - 1; i >= 0; i-- {
+		ignores, err := f.ignoreFilesIn(dirs[i])
+		if err != nil {
+			return false, err
+		}
+		for _, ignore := range ignores {
+			if result, decided := ignore.match(absPath, isDir); decided {
+				ignored = result
+			}
+		}
+	}
+	return ignored, nil
+}
+
+// isBelow reports whether path is inside dir.
+func isBelow(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// ignoreFilesIn returns the ignore files in a directory.
+func (f *Finder) ignoreFilesIn(dir string) ([]*ignoreFile, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("resolving directory path %s: %w", dir, err)
+	}
+	if cached, ok := f.ignores[absDir]; ok {
+		return cached, nil
+	}
+
+	var found []*ignoreFile
+	for _, name := range f.ignoreNames {
+		ignore, err := loadIgnoreFile(filepath.Join(absDir, name))
+		if err != nil {
+			return nil, err
+		}
+		if ignore != nil {
+			found = append(found, ignore)
+		}
+	}
+
+	f.ignores[absDir] = found
+	return found, nil
+}
@@ -0,0 +1,122 @@
+package discovery
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// writeTree creates files (with empty content unless given) under dir.
+func writeTree(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// relative converts found files to slash paths relative to dir.
+func relative(t *testing.T, dir string, files []string) []string {
+	t.Helper()
+	var rel []string
+	for _, f := range files {
+		r, err := filepath.Rel(dir, f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rel = append(rel, filepath.ToSlash(r))
+	}
+	sort.Strings(rel)
+	return rel
+}
+
+func TestFind(t *testing.T) {
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{
+		"rules/a.grl":              "",
+		"rules/b.rules":            "",
+		"rules/notes.txt":          "",
+		"rules/vendor/c.grl":       "",
+		"rules/generated/d.grl":    "",
+		"rules/generated/keep.grl": "",
+		"rules/tmp/e.grl":          "",
+		".grl-lintignore":          "# generated code\nrules/generated/\n!rules/generated/keep.grl\n",
+		"rules/.grl-lintignore":    "tmp/\n",
+		".gitignore":               "b.rules\n",
+	})
+
+	tests := []struct {
+		name     string
+		opts     Options
+		expected []string
+	}{
+		{
+			name:     "defaults",
+			opts:     Options{},
+			expected: []string{"rules/a.grl", "rules/vendor/c.grl"},
+		},
+		{
+			name:     "include and exclude",
+			opts:     Options{Include: []string{"**/*.grl", "**/*.rules"}, Exclude: []string{"**/vendor/**"}},
+			expected: []string{"rules/a.grl", "rules/b.rules"},
+		},
+		{
+			name:     "gitignore",
+			opts:     Options{Include: []string{"**/*.grl", "**/*.rules"}, Gitignore: true},
+			expected: []string{"rules/a.grl", "rules/vendor/c.grl"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.Root = dir
+			files, err := New(tt.opts).Find([]string{filepath.Join(dir, "rules")})
+			if err != nil {
+				t.Fatalf("Find failed: %v", err)
+			}
+			if got := relative(t, dir, files); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestFind_ExplicitFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{
+		"main.rules":      "",
+		"skip.grl":        "",
+		"build/out.grl":   "",
+		"build/a/b.grl":   "",
+		".grl-lintignore": "skip.grl\nbuild/\n",
+	})
+
+	files, err := New(Options{Root: dir}).Find([]string{
+		filepath.Join(dir, "main.rules"),
+		filepath.Join(dir, "skip.grl"),
+		filepath.Join(dir, "build", "out.grl"),
+		filepath.Join(dir, "build", "a", "b.grl"),
+	})
+	if err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+
+	// Explicit files bypass include patterns but not ignore files, including
+	// directory patterns matching one of their directories
+	if got := relative(t, dir, files); !reflect.DeepEqual(got, []string{"main.rules"}) {
+		t.Errorf("expected [main.rules], got %v", got)
+	}
+}
+
+func TestFind_MissingPath(t *testing.T) {
+	if _, err := New(Options{}).Find([]string{filepath.Join(t.TempDir(), "missing")}); err == nil {
+		t.Error("expected error for missing path")
+	}
+}
@@ -0,0 +1,106 @@
+package discovery
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bmatcuk/doublestar"
+)
+
+// ignorePattern is a single line of a gitignore-style file.
+type ignorePattern struct {
+	glob    string // doublestar pattern relative to the ignore file's directory
+	negate  bool
+	dirOnly bool
+}
+
+// ignoreFile holds the patterns of one ignore file, which apply to paths
+// below its directory.
+type ignoreFile struct {
+	dir      string // absolute directory of the ignore file
+	patterns []ignorePattern
+}
+
+// loadIgnoreFile reads a gitignore-style file. A missing file is not an
+// error and returns nil.
+func loadIgnoreFile(path string) (*ignoreFile, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading ignore file %s: %w", path, err)
+	}
+	defer func() { _ = f.Close() }()
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("resolving ignore file path %s: %w", path, err)
+	}
+
+	ignore := &ignoreFile{dir: filepath.Dir(absPath)}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if p, ok := parseIgnorePattern(scanner.Text()); ok {
+			ignore.patterns = append(ignore.patterns, p)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading ignore file %s: %w", path, err)
+	}
+	return ignore, nil
+}
+
+// parseIgnorePattern converts a gitignore line to a pattern. Blank lines
+// and comments are skipped.
+func parseIgnorePattern(line string) (ignorePattern, bool) {
+	line = strings.TrimRight(line, " \t\r")
+	if line == "" || strings.HasPrefix(line, "#") {
+		return ignorePattern{}, false
+	}
+
+	var p ignorePattern
+	if strings.HasPrefix(line, "!") {
+		p.negate = true
+		line = line[1:]
+	}
+	line = strings.TrimPrefix(line, `\`)
+
+	if strings.HasSuffix(line, "/") {
+		p.dirOnly = true
+		line = strings.TrimSuffix(line, "/")
+	}
+
+	// A pattern containing a slash is relative to the ignore file;
+	// otherwise it matches at any depth.
+	if strings.Contains(line, "/") {
+		p.glob = strings.TrimPrefix(line, "/")
+	} else {
+		p.glob = "**/" + line
+	}
+
+	return p, line != ""
+}
+
+// match reports whether the ignore file decides on a path, and if so
+// whether the path is ignored. The last matching pattern wins.
+func (f *ignoreFile) match(absPath string, isDir bool) (ignored, decided bool) {
+	rel, err := filepath.Rel(f.dir, absPath)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return false, false
+	}
+	rel = filepath.ToSlash(rel)
+
+	for _, p := range f.patterns {
+		if p.dirOnly && !isDir {
+			continue
+		}
+		if matched, _ := doublestar.Match(p.glob, rel); matched {
+			ignored, decided = !p.negate, true
+		}
+	}
+	return ignored, decided
+}
@@ -3,11 +3,11 @@ package linter
 
 import (
 	"fmt"
-	"os"
-	"path/filepath"
-	"strings"
+	"slices"
 
+	"github.com/adarshjos/grule-lint/internal/config"
 	"github.com/adarshjos/grule-lint/internal/diagnostic"
+	"github.com/adarshjos/grule-lint/internal/discovery"
 	"github.com/adarshjos/grule-lint/internal/parser"
 	"github.com/adarshjos/grule-lint/internal/rules"
 )
@@ -16,6 +16,29 @@ import (
 type Linter struct {
 	parser   *parser.Parser
 	registry *rules.Registry
+	finder   *discovery.Finder
+
+	// resolve returns the rule configuration for a file. If nil, registry
+	// is used for every file.
+	resolve func(file string) rules.RegistryConfig
+
+	// registries caches registries keyed by the formatted configuration,
+	// since configurations holding rule options are not comparable.
+	registries map[string]*rules.Registry
+
+	// sources holds the content of every file linted so far, keyed by
+	// file name, so reporters can render source excerpts.
+	sources map[string]string
+
+	// ruleInfos holds the GRL rules of every file linted so far.
+	ruleInfos map[string][]parser.RuleInfo
+
+	// knowledgeBases lists the files loaded together by the application.
+	knowledgeBases []config.KnowledgeBaseConfig
+
+	// configErr is the first error configuring the rules, returned by the
+	// methods that lint files.
+	configErr error
 }
 
 // New creates a new Linter with the default registry.
@@ -35,13 +58,54 @@ func NewWithRegistry(registry *rules.Registry) *Linter {
 }
 
 // NewWithConfig creates a new Linter with the specified configuration.
+// Invalid rule options are reported by LintFile, LintFiles and LintPaths.
 func NewWithConfig(cfg rules.RegistryConfig) *Linter {
-	return &Linter{
-		parser:   parser.NewParser(),
-		registry: rules.DefaultRegistryWithConfig(cfg),
+	return NewWithConfigResolver(func(string) rules.RegistryConfig { return cfg })
+}
+
+// configError wraps an error configuring the rules for a file.
+func configError(file string, err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case file == "":
+		return fmt.Errorf("configuring rules: %w", err)
+	default:
+		return fmt.Errorf("configuring rules for %s: %w", file, err)
 	}
 }
 
+// NewWithConfigResolver creates a new Linter that resolves the rule
+// configuration per file, e.g. from path-specific config overrides.
+// Registries are cached per distinct configuration. Invalid rule options
+// are reported by LintFile, LintFiles and LintPaths.
+func NewWithConfigResolver(resolve func(file string) rules.RegistryConfig) *Linter {
+	l := &Linter{
+		parser:     parser.NewParser(),
+		resolve:    resolve,
+		registries: make(map[string]*rules.Registry),
+	}
+	l.registry = l.registryFor("")
+	return l
+}
+
+// SetKnowledgeBases sets the knowledge bases whose files are checked
+// together by LintFiles, LintDirectory and LintPaths.
+func (l *Linter) SetKnowledgeBases(kbs []config.KnowledgeBaseConfig) {
+	l.knowledgeBases = kbs
+}
+
+// SetFinder sets the component used to find files in LintDirectory and
+// LintPaths. By default all .grl files are linted.
+func (l *Linter) SetFinder(finder *discovery.Finder) {
+	l.finder = finder
+}
+
+// Registry returns the rule registry used by the linter.
+func (l *Linter) Registry() *rules.Registry {
+	return l.registry
+}
+
 // LintFile lints a single GRL file.
 func (l *Linter) LintFile(file string) (*diagnostic.DiagnosticSet, error) {
 	result, err := l.parser.ParseFile(file)
@@ -49,7 +113,11 @@ func (l *Linter) LintFile(file string) (*diagnostic.DiagnosticSet, error) {
 		return nil, err
 	}
 
-	return l.lintParseResult(result), nil
+	ds := l.lintParseResult(result)
+	if l.configErr != nil {
+		return nil, l.configErr
+	}
+	return ds, nil
 }
 
 // LintString lints GRL content from a string.
@@ -58,82 +126,172 @@ func (l *Linter) LintString(file, content string) *diagnostic.DiagnosticSet {
 	return l.lintParseResult(result)
 }
 
-// lintParseResult runs all applicable rules on a parse result.
+// Source returns the content of a file previously linted by this linter.
+func (l *Linter) Source(file string) (string, bool) {
+	content, ok := l.sources[file]
+	return content, ok
+}
+
+// RuleAt returns the name of the GRL rule enclosing a position in a file
+// previously linted by this linter, or an empty string if there is none.
+func (l *Linter) RuleAt(file string, pos diagnostic.Position) string {
+	for _, info := range l.ruleInfos[file] {
+		if !pos.Before(info.Position) && !info.EndPosition.Before(pos) {
+			return info.Name
+		}
+	}
+	return ""
+}
+
+// registryFor returns the rule registry to use for a file.
+func (l *Linter) registryFor(file string) *rules.Registry {
+	if l.resolve == nil {
+		return l.registry
+	}
+
+	cfg := l.resolve(file)
+	key := fmt.Sprintf("%+v", cfg)
+	registry, ok := l.registries[key]
+	if !ok {
+		var err error
+		registry, err = rules.DefaultRegistryWithConfig(cfg)
+		if l.configErr == nil {
+			l.configErr = configError(file, err)
+		}
+		l.registries[key] = registry
+	}
+	return registry
+}
+
+// lintParseResult runs all applicable rules on a parse result, including
+// the project rules on a project of this file alone.
 func (l *Linter) lintParseResult(result *parser.ParseResult) *diagnostic.DiagnosticSet {
-	ds := diagnostic.NewDiagnosticSet()
+	diags := l.checkParseResult(result)
+	diags = append(diags, l.registryFor(result.File).RunProjectRules(l.newProject([]*parser.ParseResult{result}))...)
+	return l.applySuppressions(result, diags)
+}
+
+// checkParseResult records a parse result and runs the per-file rules on it.
+func (l *Linter) checkParseResult(result *parser.ParseResult) []diagnostic.Diagnostic {
+	if l.sources == nil {
+		l.sources = make(map[string]string)
+	}
+	l.sources[result.File] = result.Source
 
+	if l.ruleInfos == nil {
+		l.ruleInfos = make(map[string][]parser.RuleInfo)
+	}
+	l.ruleInfos[result.File] = result.Rules
+
+	registry := l.registryFor(result.File)
 	if len(result.Errors) > 0 {
 		// Run syntax rules on parse errors
-		diags := l.registry.RunSyntaxRules(result)
-		ds.AddAll(diags)
-	} else {
-		// No parse errors - run semantic rules
-		// Semantic rules use result.Rules (from ANTLR) not necessarily the KB
-		diags := l.registry.RunSemanticRules(result)
-		ds.AddAll(diags)
+		return registry.RunSyntaxRules(result)
 	}
 
+	// No parse errors - run semantic rules
+	// Semantic rules use result.Rules (from ANTLR) not necessarily the KB
+	return registry.RunSemanticRules(result)
+}
+
+// applySuppressions drops the diagnostics of a file silenced by its
+// suppression comments. Unused suppressions are only reported when the
+// file parsed, since semantic rules do not run otherwise.
+func (l *Linter) applySuppressions(result *parser.ParseResult, diags []diagnostic.Diagnostic) *diagnostic.DiagnosticSet {
+	kept, unused := applySuppressions(result.Suppressions, result.Rules, diags)
+	if len(result.Errors) == 0 {
+		kept = append(kept, l.registryFor(result.File).RunSuppressionRules(result, unused)...)
+	}
+
+	ds := diagnostic.NewDiagnosticSet()
+	ds.AddFiles(result.File)
+	ds.AddAll(l.applyRuleSeverities(result.File, kept))
 	return ds
 }
 
-// LintFiles lints multiple GRL files.
+// applyRuleSeverities drops the diagnostics of rules turned off for a file
+// and sets the configured severity of the others. It runs after the
+// suppressions, so that suppressing a rule that is off is not reported as
+// unused.
+func (l *Linter) applyRuleSeverities(file string, diags []diagnostic.Diagnostic) []diagnostic.Diagnostic {
+	if l.resolve == nil {
+		return diags
+	}
+
+	cfg := &config.Config{Rules: l.resolve(file).Rules}
+	kept := make([]diagnostic.Diagnostic, 0, len(diags))
+	for _, d := range diags {
+		severity := cfg.GetRuleSeverity(d.RuleID, d.Severity)
+		if severity == nil {
+			continue
+		}
+		d.Severity = *severity
+		kept = append(kept, d)
+	}
+	return kept
+}
+
+// LintFiles lints multiple GRL files. After the per-file rules, project
+// rules check all files together, grouped by knowledge base.
 func (l *Linter) LintFiles(files []string) (*diagnostic.DiagnosticSet, error) {
-	ds := diagnostic.NewDiagnosticSet()
+	results := make([]*parser.ParseResult, 0, len(files))
+	diags := make(map[string][]diagnostic.Diagnostic, len(files))
 
 	for _, file := range files {
-		fileDiags, err := l.LintFile(file)
+		result, err := l.parser.ParseFile(file)
 		if err != nil {
 			return nil, err
 		}
-		ds.AddAll(fileDiags.All())
+		results = append(results, result)
+		diags[file] = append(diags[file], l.checkParseResult(result)...)
 	}
 
-	return ds, nil
-}
-
-// LintDirectory lints all GRL files in a directory (recursively).
-func (l *Linter) LintDirectory(dir string) (*diagnostic.DiagnosticSet, error) {
-	var files []string
-
-	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return fmt.Errorf("walking directory: %w", err)
+	// Project rules run with the registry of every distinct configuration,
+	// keeping the diagnostics of the files using it, so that path-specific
+	// rule options apply to findings across files
+	project := l.newProject(results)
+	var registries []*rules.Registry
+	for _, result := range results {
+		if registry := l.registryFor(result.File); !slices.Contains(registries, registry) {
+			registries = append(registries, registry)
 		}
-		if !info.IsDir() && strings.HasSuffix(path, ".grl") {
-			files = append(files, path)
+	}
+	for _, registry := range registries {
+		for _, d := range registry.RunProjectRules(project) {
+			if l.registryFor(d.File) == registry {
+				diags[d.File] = append(diags[d.File], d)
+			}
 		}
-		return nil
-	})
+	}
 
-	if err != nil {
-		return nil, fmt.Errorf("scanning directory %s: %w", dir, err)
+	ds := diagnostic.NewDiagnosticSet()
+	for _, result := range results {
+		ds.AddFiles(result.File)
+		ds.AddAll(l.applySuppressions(result, diags[result.File]).All())
 	}
 
-	return l.LintFiles(files)
+	if l.configErr != nil {
+		return nil, l.configErr
+	}
+	return ds, nil
+}
+
+// LintDirectory lints all GRL files in a directory (recursively).
+func (l *Linter) LintDirectory(dir string) (*diagnostic.DiagnosticSet, error) {
+	return l.LintPaths([]string{dir})
 }
 
 // LintPaths lints files and/or directories.
 func (l *Linter) LintPaths(paths []string) (*diagnostic.DiagnosticSet, error) {
-	ds := diagnostic.NewDiagnosticSet()
-
-	for _, path := range paths {
-		info, err := os.Stat(path)
-		if err != nil {
-			return nil, fmt.Errorf("accessing path %s: %w", path, err)
-		}
-
-		var pathDiags *diagnostic.DiagnosticSet
-		if info.IsDir() {
-			pathDiags, err = l.LintDirectory(path)
-		} else {
-			pathDiags, err = l.LintFile(path)
-		}
+	finder := l.finder
+	if finder == nil {
+		finder = discovery.New(discovery.Options{})
+	}
 
-		if err != nil {
-			return nil, err
-		}
-		ds.AddAll(pathDiags.All())
+	files, err := finder.Find(paths)
+	if err != nil {
+		return nil, err
 	}
 
-	return ds, nil
+	return l.LintFiles(files)
 }
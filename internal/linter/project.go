@@ -0,0 +1,36 @@
+package linter
+
+import (
+	"github.com/adarshjos/grule-lint/internal/config"
+	"github.com/adarshjos/grule-lint/internal/parser"
+	"github.com/adarshjos/grule-lint/internal/rules"
+)
+
+// newProject groups the parse results of a lint run by knowledge base and
+// builds each knowledge base from its files. A file may belong to several
+// knowledge bases.
+func (l *Linter) newProject(results []*parser.ParseResult) *rules.Project {
+	project := &rules.Project{Results: results}
+
+	for _, kb := range l.knowledgeBases {
+		version := kb.Version
+		if version == "" {
+			version = config.DefaultKnowledgeBaseVersion
+		}
+
+		group := &rules.KnowledgeBaseGroup{Name: kb.Name, Version: version}
+		for _, result := range results {
+			if config.MatchAny(kb.Files, result.File) {
+				group.Results = append(group.Results, result)
+			}
+		}
+		if len(group.Results) == 0 {
+			continue
+		}
+
+		group.KnowledgeBase = parser.BuildKnowledgeBase(kb.Name, version, group.Results)
+		project.KnowledgeBases = append(project.KnowledgeBases, group)
+	}
+
+	return project
+}
@@ -0,0 +1,148 @@
+package linter
+
+import (
+	"math"
+
+	"github.com/adarshjos/grule-lint/internal/diagnostic"
+	"github.com/adarshjos/grule-lint/internal/parser"
+	"github.com/adarshjos/grule-lint/internal/rules"
+)
+
+// suppressionScope is the range of lines a suppression directive silences
+// for one rule.
+type suppressionScope struct {
+	directive int    // index into ParseResult.Suppressions
+	ruleID    string // empty for all rules
+	from, to  int    // inclusive line range
+	used      bool
+}
+
+// matches reports whether the scope silences a diagnostic.
+func (s *suppressionScope) matches(d diagnostic.Diagnostic) bool {
+	line := d.Range.Start.Line
+	return (s.ruleID == "" || s.ruleID == d.RuleID) && line >= s.from && line <= s.to
+}
+
+// applySuppressions removes diagnostics silenced by suppression comments and
+// returns the remaining diagnostics along with the unused suppressions.
+func applySuppressions(suppressions []parser.Suppression, ruleInfos []parser.RuleInfo, diags []diagnostic.Diagnostic) ([]diagnostic.Diagnostic, []rules.UnusedSuppression) {
+	if len(suppressions) == 0 {
+		return diags, nil
+	}
+
+	scopes, unusedEnds := buildScopes(suppressions, ruleInfos)
+
+	var kept []diagnostic.Diagnostic
+	for _, d := range diags {
+		suppressed := false
+		for i := range scopes {
+			if scopes[i].matches(d) {
+				scopes[i].used = true
+				suppressed = true
+			}
+		}
+		if !suppressed {
+			kept = append(kept, d)
+		}
+	}
+
+	// Collect unused rule IDs per directive, in source order
+	unusedIDs := make(map[int][]string)
+	allUnused := make(map[int]bool)
+	for _, scope := range scopes {
+		if scope.used {
+			continue
+		}
+		if scope.ruleID == "" {
+			allUnused[scope.directive] = true
+		} else {
+			unusedIDs[scope.directive] = append(unusedIDs[scope.directive], scope.ruleID)
+		}
+	}
+
+	var unused []rules.UnusedSuppression
+	for i, s := range suppressions {
+		switch {
+		case s.Kind == parser.SuppressEnd:
+			if unusedEnds[i] {
+				unused = append(unused, rules.UnusedSuppression{Suppression: s, RuleIDs: s.RuleIDs})
+			}
+		case allUnused[i]:
+			unused = append(unused, rules.UnusedSuppression{Suppression: s})
+		case len(unusedIDs[i]) > 0:
+			unused = append(unused, rules.UnusedSuppression{Suppression: s, RuleIDs: unusedIDs[i]})
+		}
+	}
+
+	return kept, unused
+}
+
+// buildScopes converts suppression directives into line scopes. A
+// next-line directive directly above a rule header covers the whole rule,
+// since rules report some issues at its when or then clause. It also
+// returns the enable directives that did not end any suppression.
+func buildScopes(suppressions []parser.Suppression, ruleInfos []parser.RuleInfo) ([]suppressionScope, map[int]bool) {
+	var scopes []suppressionScope
+	var open []int // indexes into scopes of unterminated disable blocks
+	unusedEnds := make(map[int]bool)
+
+	add := func(directive int, ids []string, from, to int) {
+		if len(ids) == 0 {
+			ids = []string{""}
+		}
+		for _, id := range ids {
+			scopes = append(scopes, suppressionScope{directive: directive, ruleID: id, from: from, to: to})
+		}
+	}
+
+	for i, s := range suppressions {
+		switch s.Kind {
+		case parser.SuppressNextLine:
+			next := s.Range.End.Line + 1
+			to := next
+			for _, info := range ruleInfos {
+				if info.Position.Line == next {
+					to = max(to, info.EndPosition.Line)
+				}
+			}
+			add(i, s.RuleIDs, next, to)
+
+		case parser.SuppressFile:
+			add(i, s.RuleIDs, 1, math.MaxInt)
+
+		case parser.SuppressStart:
+			first := len(scopes)
+			add(i, s.RuleIDs, s.Range.Start.Line, math.MaxInt)
+			for j := first; j < len(scopes); j++ {
+				open = append(open, j)
+			}
+
+		case parser.SuppressEnd:
+			closed := false
+			remaining := open[:0]
+			for _, j := range open {
+				if len(s.RuleIDs) == 0 || containsID(s.RuleIDs, scopes[j].ruleID) {
+					scopes[j].to = s.Range.Start.Line
+					closed = true
+				} else {
+					remaining = append(remaining, j)
+				}
+			}
+			open = remaining
+			if !closed {
+				unusedEnds[i] = true
+			}
+		}
+	}
+
+	return scopes, unusedEnds
+}
+
+func containsID(ids []string, id string) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
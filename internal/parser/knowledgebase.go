@@ -0,0 +1,26 @@
+package parser
+
+import (
+	gruleAst "github.com/hyperjumptech/grule-rule-engine/ast"
+	"github.com/hyperjumptech/grule-rule-engine/builder"
+	"github.com/hyperjumptech/grule-rule-engine/pkg"
+)
+
+// BuildKnowledgeBase loads the given files into one knowledge base, in
+// order, as an application does with several resources under the same name
+// and version. Files with parse errors are skipped. Grule's errors for
+// files that parse are not returned: they only report rule names already
+// in the knowledge base, which GRL005 checks.
+func BuildKnowledgeBase(name, version string, results []*ParseResult) *gruleAst.KnowledgeBase {
+	lib := gruleAst.NewKnowledgeLibrary()
+	rb := builder.NewRuleBuilder(lib)
+
+	for _, result := range results {
+		if len(result.Errors) > 0 {
+			continue
+		}
+		_ = rb.BuildRuleFromResource(name, version, pkg.NewBytesResource([]byte(result.Source)))
+	}
+
+	return lib.GetKnowledgeBase(name, version)
+}
@@ -1,6 +1,10 @@
 package parser
 
 import (
+	"strconv"
+	"strings"
+
+	"github.com/antlr/antlr4/runtime/Go/antlr"
 	"github.com/hyperjumptech/grule-rule-engine/antlr/parser/grulev3"
 
 	"github.com/adarshjos/grule-lint/internal/diagnostic"
@@ -18,22 +22,66 @@ type RuleInfo struct {
 	ThenPosition diagnostic.Position
 
 	FunctionCalls       []FunctionCallInfo
+	MethodCalls         []FunctionCallInfo
 	ConditionCount      int
 	HasWhenExpression   bool
 	ThenActionCount     int
 	VariableAssignments []VariableInfo
 	VariableUsages      []VariableInfo
 	WhenExpressionText  string
+
+	// ConditionReads holds the variables read by the when clause. Unlike
+	// VariableUsages, a member chain such as Order.Items[0].Name appears
+	// once rather than once per prefix.
+	ConditionReads []VariableInfo
 }
 
+// FunctionCallInfo describes a function or method call. FunctionCalls
+// holds the calls of a then clause, including method calls; MethodCalls
+// holds the method calls of both clauses.
 type FunctionCallInfo struct {
 	Name     string
 	Position diagnostic.Position
+
+	// Receiver is the source text of the value a method is called on,
+	// e.g. "Order.Items" for Order.Items.Len(). Empty for functions.
+	Receiver string
+
+	// ReceiverPath is the member path of the receiver if it is a variable,
+	// and nil otherwise (e.g. for the result of another call).
+	ReceiverPath []PathSegment
+
+	// Arguments holds the source text of each argument, and ArgumentRanges
+	// where each argument is in the source.
+	Arguments      []string
+	ArgumentRanges []diagnostic.Range
+}
+
+// StringArgument returns the value of the i-th argument if it is a string
+// literal, e.g. NoDescription for Retract("NoDescription").
+func (c FunctionCallInfo) StringArgument(i int) (string, bool) {
+	if i >= len(c.Arguments) {
+		return "", false
+	}
+	return unquote(c.Arguments[i])
 }
 
 type VariableInfo struct {
 	Name     string
 	Position diagnostic.Position
+
+	// Path is the variable split into members, e.g. Order, Items, [],
+	// Name for Order.Items[0].Name.
+	Path []PathSegment
+}
+
+// PathSegment is one step of a variable: its base name, a member or an
+// index selector.
+type PathSegment struct {
+	// Name is the variable or member name; empty for index selectors.
+	Name     string
+	Index    bool
+	Position diagnostic.Position
 }
 
 // LintListener extracts rule information from the ANTLR parse tree.
@@ -120,11 +168,37 @@ func (l *LintListener) ExitThenScope(ctx *grulev3.ThenScopeContext) {
 }
 
 func (l *LintListener) EnterFunctionCall(ctx *grulev3.FunctionCallContext) {
-	if l.currentRule != nil && l.inThenScope {
-		l.currentRule.FunctionCalls = append(l.currentRule.FunctionCalls, FunctionCallInfo{
-			Name:     ctx.SIMPLENAME().GetText(),
-			Position: diagnostic.Position{Line: ctx.GetStart().GetLine(), Column: ctx.GetStart().GetColumn() + 1},
-		})
+	if l.currentRule == nil {
+		return
+	}
+
+	call := FunctionCallInfo{
+		Name:     ctx.SIMPLENAME().GetText(),
+		Position: diagnostic.Position{Line: ctx.GetStart().GetLine(), Column: ctx.GetStart().GetColumn() + 1},
+	}
+	if args, ok := ctx.ArgumentList().(*grulev3.ArgumentListContext); ok {
+		for _, arg := range args.AllExpression() {
+			call.Arguments = append(call.Arguments, arg.GetText())
+			call.ArgumentRanges = append(call.ArgumentRanges, tokenRange(arg.GetStart(), arg.GetStop()))
+		}
+	}
+
+	// A method call is the last child of an expression atom whose first
+	// child is the receiver
+	if _, ok := ctx.GetParent().(*grulev3.MethodCallContext); ok {
+		if atom, ok := ctx.GetParent().GetParent().(*grulev3.ExpressionAtomContext); ok {
+			if receiver, ok := atom.ExpressionAtom().(*grulev3.ExpressionAtomContext); ok {
+				call.Receiver = receiver.GetText()
+				if v, ok := receiver.Variable().(*grulev3.VariableContext); ok {
+					call.ReceiverPath = variablePath(v)
+				}
+			}
+		}
+		l.currentRule.MethodCalls = append(l.currentRule.MethodCalls, call)
+	}
+
+	if l.inThenScope {
+		l.currentRule.FunctionCalls = append(l.currentRule.FunctionCalls, call)
 	}
 }
 
@@ -142,10 +216,11 @@ func (l *LintListener) EnterAssignment(ctx *grulev3.AssignmentContext) {
 	if l.currentRule != nil && l.inThenScope {
 		l.currentRule.ThenActionCount++
 		// Track the assigned variable for GRL008 (unused-variable) detection
-		if ctx.Variable() != nil {
+		if v, ok := ctx.Variable().(*grulev3.VariableContext); ok {
 			l.currentRule.VariableAssignments = append(l.currentRule.VariableAssignments, VariableInfo{
-				Name:     ctx.Variable().GetText(),
+				Name:     v.GetText(),
 				Position: diagnostic.Position{Line: ctx.GetStart().GetLine(), Column: ctx.GetStart().GetColumn() + 1},
+				Path:     variablePath(v),
 			})
 		}
 	}
@@ -155,8 +230,84 @@ func (l *LintListener) EnterVariable(ctx *grulev3.VariableContext) {
 	if l.currentRule == nil {
 		return
 	}
-	l.currentRule.VariableUsages = append(l.currentRule.VariableUsages, VariableInfo{
+	v := VariableInfo{
 		Name:     ctx.GetText(),
 		Position: diagnostic.Position{Line: ctx.GetStart().GetLine(), Column: ctx.GetStart().GetColumn() + 1},
-	})
+		Path:     variablePath(ctx),
+	}
+	l.currentRule.VariableUsages = append(l.currentRule.VariableUsages, v)
+
+	// Inner variables of a member chain are prefixes of the outer one
+	if _, inner := ctx.GetParent().(*grulev3.VariableContext); l.inWhenScope && !inner {
+		l.currentRule.ConditionReads = append(l.currentRule.ConditionReads, v)
+	}
+}
+
+// variablePath splits a variable into its base name, members and index
+// selectors.
+func variablePath(ctx *grulev3.VariableContext) []PathSegment {
+	if name := ctx.SIMPLENAME(); name != nil {
+		return []PathSegment{{
+			Name:     name.GetText(),
+			Position: diagnostic.Position{Line: name.GetSymbol().GetLine(), Column: name.GetSymbol().GetColumn() + 1},
+		}}
+	}
+
+	inner, ok := ctx.Variable().(*grulev3.VariableContext)
+	if !ok {
+		return nil
+	}
+	path := variablePath(inner)
+
+	if member, ok := ctx.MemberVariable().(*grulev3.MemberVariableContext); ok {
+		name := member.SIMPLENAME()
+		return append(path, PathSegment{
+			Name:     name.GetText(),
+			Position: diagnostic.Position{Line: name.GetSymbol().GetLine(), Column: name.GetSymbol().GetColumn() + 1},
+		})
+	}
+	if selector := ctx.ArrayMapSelector(); selector != nil {
+		return append(path, PathSegment{
+			Index:    true,
+			Position: diagnostic.Position{Line: selector.GetStart().GetLine(), Column: selector.GetStart().GetColumn() + 1},
+		})
+	}
+	return path
+}
+
+// tokenRange returns the source range from the start of one token to the
+// end of another.
+func tokenRange(start, stop antlr.Token) diagnostic.Range {
+	end := diagnostic.Position{Line: stop.GetLine(), Column: stop.GetColumn() + 1}
+	text := stop.GetText()
+	if i := strings.LastIndex(text, "\n"); i >= 0 {
+		end.Line += strings.Count(text, "\n")
+		end.Column = len(text) - i
+	} else {
+		end.Column += len(text)
+	}
+	return diagnostic.Range{
+		Start: diagnostic.Position{Line: start.GetLine(), Column: start.GetColumn() + 1},
+		End:   end,
+	}
+}
+
+// unquote returns the value of a GRL string literal. Literals may be
+// enclosed in double or single quotes.
+func unquote(text string) (string, bool) {
+	if len(text) < 2 {
+		return "", false
+	}
+	switch {
+	case text[0] == '"' && text[len(text)-1] == '"':
+		value, err := strconv.Unquote(text)
+		return value, err == nil
+	case text[0] == '\'' && text[len(text)-1] == '\'':
+		value := text[1 : len(text)-1]
+		if strings.ContainsRune(value, '\'') {
+			return "", false
+		}
+		return value, true
+	}
+	return "", false
 }
@@ -34,6 +34,9 @@ type ParseResult struct {
 	// Errors contains any parse errors encountered
 	Errors []ParseError
 
+	// Suppressions contains the grule-lint directives found in comments
+	Suppressions []Suppression
+
 	// Source is the original source content
 	Source string
 
@@ -63,8 +66,9 @@ func NewParser() *Parser {
 // ParseString parses GRL content from a string.
 func (p *Parser) ParseString(file, content string) *ParseResult {
 	result := &ParseResult{
-		Source: content,
-		File:   file,
+		Source:       content,
+		File:         file,
+		Suppressions: scanSuppressions(content),
 	}
 
 	rules, errors := p.parseWithANTLR(content)
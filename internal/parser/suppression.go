@@ -0,0 +1,178 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/adarshjos/grule-lint/internal/diagnostic"
+)
+
+// suppressionPrefix starts every suppression directive comment.
+const suppressionPrefix = "grule-lint-"
+
+// SuppressionKind identifies the type of a suppression directive.
+type SuppressionKind int
+
+const (
+	// SuppressNextLine silences diagnostics on the line after the comment.
+	SuppressNextLine SuppressionKind = iota
+	// SuppressStart silences diagnostics until a matching SuppressEnd.
+	SuppressStart
+	// SuppressEnd ends suppressions started by SuppressStart.
+	SuppressEnd
+	// SuppressFile silences diagnostics in the whole file.
+	SuppressFile
+)
+
+// suppressionKinds maps directive names to their kinds.
+var suppressionKinds = map[string]SuppressionKind{
+	"disable-next-line": SuppressNextLine,
+	"disable":           SuppressStart,
+	"enable":            SuppressEnd,
+	"disable-file":      SuppressFile,
+}
+
+// String returns the directive name for the kind.
+func (k SuppressionKind) String() string {
+	switch k {
+	case SuppressNextLine:
+		return suppressionPrefix + "disable-next-line"
+	case SuppressStart:
+		return suppressionPrefix + "disable"
+	case SuppressEnd:
+		return suppressionPrefix + "enable"
+	case SuppressFile:
+		return suppressionPrefix + "disable-file"
+	default:
+		return "unknown"
+	}
+}
+
+// Suppression is a grule-lint directive found in a GRL comment, e.g.
+// "// grule-lint-disable-next-line GRL004".
+type Suppression struct {
+	Kind SuppressionKind
+
+	// RuleIDs lists the rules the directive applies to. Empty means all rules.
+	RuleIDs []string
+
+	// Range covers the comment containing the directive.
+	Range diagnostic.Range
+
+	// OwnLine is true if the comment is the only content on its line.
+	OwnLine bool
+}
+
+// scanSuppressions finds suppression directives in the comments of a GRL
+// source. The GRL lexer discards comments, so the source is scanned
+// directly, skipping string literals.
+func scanSuppressions(content string) []Suppression {
+	var suppressions []Suppression
+
+	runes := []rune(content)
+	line, col := 1, 1
+	lineHasCode := false
+
+	// advance moves past n runes, tracking line and column.
+	advance := func(i, n int) int {
+		for end := i + n; i < end && i < len(runes); i++ {
+			if runes[i] == '\n' {
+				line++
+				col = 1
+				lineHasCode = false
+			} else {
+				col++
+			}
+		}
+		return i
+	}
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		switch {
+		case r == '"' || r == '\'':
+			// String literal: skip to the closing quote, honoring escapes
+			lineHasCode = true
+			j := i + 1
+			for j < len(runes) && runes[j] != r && runes[j] != '\n' {
+				if runes[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			i = advance(i, j-i+1)
+
+		case r == '/' && i+1 < len(runes) && (runes[i+1] == '/' || runes[i+1] == '*'):
+			block := runes[i+1] == '*'
+			start := diagnostic.Position{Line: line, Column: col}
+			ownLine := !lineHasCode
+
+			var end int
+			var text string
+			if block {
+				end = i + 2
+				for end < len(runes) && !(runes[end] == '*' && end+1 < len(runes) && runes[end+1] == '/') {
+					end++
+				}
+				text = string(runes[i+2 : end])
+				if end < len(runes) {
+					end += 2
+				}
+			} else {
+				end = i + 2
+				for end < len(runes) && runes[end] != '\n' && runes[end] != '\r' {
+					end++
+				}
+				text = string(runes[i+2 : end])
+			}
+
+			i = advance(i, end-i)
+			rest := end
+			for rest < len(runes) && (runes[rest] == ' ' || runes[rest] == '\t') {
+				rest++
+			}
+			ownLine = ownLine && (rest == len(runes) || runes[rest] == '\n' || runes[rest] == '\r')
+
+			if s, ok := parseSuppression(text); ok {
+				s.Range = diagnostic.Range{Start: start, End: diagnostic.Position{Line: line, Column: col}}
+				s.OwnLine = ownLine
+				suppressions = append(suppressions, s)
+			}
+
+		default:
+			if r != ' ' && r != '\t' && r != '\r' && r != '\n' {
+				lineHasCode = true
+			}
+			i = advance(i, 1)
+		}
+	}
+
+	return suppressions
+}
+
+// parseSuppression parses the text of a comment as a suppression directive.
+// Rule IDs may be separated by spaces or commas, and anything after "--"
+// is treated as an explanation.
+func parseSuppression(text string) (Suppression, bool) {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, suppressionPrefix) {
+		return Suppression{}, false
+	}
+	if idx := strings.Index(text, "--"); idx >= 0 {
+		text = text[:idx]
+	}
+
+	fields := strings.FieldsFunc(text, func(r rune) bool {
+		return r == ' ' || r == '\t' || r == ','
+	})
+	kind, ok := suppressionKinds[strings.TrimPrefix(fields[0], suppressionPrefix)]
+	if !ok {
+		return Suppression{}, false
+	}
+
+	s := Suppression{Kind: kind}
+	for _, id := range fields[1:] {
+		s.RuleIDs = append(s.RuleIDs, strings.ToUpper(id))
+	}
+	return s, true
+}
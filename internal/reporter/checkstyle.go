@@ -0,0 +1,97 @@
+package reporter
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+
+	"github.com/adarshjos/grule-lint/internal/diagnostic"
+)
+
+// CheckstyleReporter formats diagnostics as Checkstyle XML.
+type CheckstyleReporter struct {
+	writer io.Writer
+}
+
+// NewCheckstyleReporter creates a new CheckstyleReporter.
+func NewCheckstyleReporter(w io.Writer) *CheckstyleReporter {
+	return &CheckstyleReporter{
+		writer: w,
+	}
+}
+
+type checkstyleReport struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr"`
+	Column   int    `xml:"column,attr,omitempty"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+// Format returns the output format name.
+func (r *CheckstyleReporter) Format() string {
+	return "checkstyle"
+}
+
+// Report outputs diagnostics as Checkstyle XML, grouped per file.
+func (r *CheckstyleReporter) Report(diagnostics *diagnostic.DiagnosticSet) error {
+	report := checkstyleReport{Version: "4.3"}
+
+	for _, d := range diagnostics.Sorted() {
+		if len(report.Files) == 0 || report.Files[len(report.Files)-1].Name != d.File {
+			report.Files = append(report.Files, checkstyleFile{Name: d.File})
+		}
+		file := &report.Files[len(report.Files)-1]
+		file.Errors = append(file.Errors, checkstyleError{
+			Line:     d.Range.Start.Line,
+			Column:   d.Range.Start.Column,
+			Severity: checkstyleSeverity(d.Severity),
+			Message:  d.Message,
+			Source:   d.RuleID,
+		})
+	}
+
+	return writeXML(r.writer, report)
+}
+
+// checkstyleSeverity maps a severity to a Checkstyle severity.
+func checkstyleSeverity(s diagnostic.Severity) string {
+	switch s {
+	case diagnostic.SeverityError:
+		return "error"
+	case diagnostic.SeverityWarning:
+		return "warning"
+	default:
+		return "info"
+	}
+}
+
+// writeXML writes v as an indented XML document with a header.
+func writeXML(w io.Writer, v any) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("writing XML header: %w", err)
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return fmt.Errorf("writing XML report: %w", err)
+	}
+
+	if _, err := fmt.Fprintln(w); err != nil {
+		return fmt.Errorf("writing newline: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package reporter
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/adarshjos/grule-lint/internal/diagnostic"
+)
+
+// GitHubReporter formats diagnostics as GitHub Actions workflow commands so
+// that they appear as inline annotations on pull requests.
+type GitHubReporter struct {
+	writer io.Writer
+}
+
+// NewGitHubReporter creates a new GitHubReporter.
+func NewGitHubReporter(w io.Writer) *GitHubReporter {
+	return &GitHubReporter{
+		writer: w,
+	}
+}
+
+// Format returns the output format name.
+func (r *GitHubReporter) Format() string {
+	return "github"
+}
+
+// Report outputs diagnostics as GitHub Actions workflow commands.
+func (r *GitHubReporter) Report(diagnostics *diagnostic.DiagnosticSet) error {
+	for _, d := range diagnostics.Sorted() {
+		if _, err := fmt.Fprintln(r.writer, r.formatDiagnostic(d)); err != nil {
+			return fmt.Errorf("writing diagnostic: %w", err)
+		}
+	}
+	return nil
+}
+
+// formatDiagnostic formats a single diagnostic.
+func (r *GitHubReporter) formatDiagnostic(d diagnostic.Diagnostic) string {
+	// Format: ::level file=F,line=L,col=C,endLine=EL,endColumn=EC,title=T::message
+	props := []string{
+		"file=" + escapeGitHubProperty(d.File),
+		fmt.Sprintf("line=%d", d.Range.Start.Line),
+		fmt.Sprintf("col=%d", d.Range.Start.Column),
+	}
+	if d.Range.End.Line > 0 {
+		props = append(props,
+			fmt.Sprintf("endLine=%d", d.Range.End.Line),
+			fmt.Sprintf("endColumn=%d", d.Range.End.Column),
+		)
+	}
+
+	title := d.RuleID
+	if d.RuleName != "" {
+		title += " " + d.RuleName
+	}
+	props = append(props, "title="+escapeGitHubProperty(title))
+
+	return fmt.Sprintf("::%s %s::%s",
+		githubLevel(d.Severity),
+		strings.Join(props, ","),
+		escapeGitHubData(d.Message),
+	)
+}
+
+// githubLevel maps a severity to a workflow command name.
+func githubLevel(s diagnostic.Severity) string {
+	switch s {
+	case diagnostic.SeverityError:
+		return "error"
+	case diagnostic.SeverityWarning:
+		return "warning"
+	default:
+		return "notice"
+	}
+}
+
+// escapeGitHubData escapes a workflow command message.
+func escapeGitHubData(s string) string {
+	s = strings.ReplaceAll(s, "%", "%25")
+	s = strings.ReplaceAll(s, "\r", "%0D")
+	return strings.ReplaceAll(s, "\n", "%0A")
+}
+
+// escapeGitHubProperty escapes a workflow command property value.
+func escapeGitHubProperty(s string) string {
+	s = escapeGitHubData(s)
+	s = strings.ReplaceAll(s, ":", "%3A")
+	return strings.ReplaceAll(s, ",", "%2C")
+}
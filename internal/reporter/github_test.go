@@ -0,0 +1,52 @@
+package reporter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/adarshjos/grule-lint/internal/diagnostic"
+)
+
+func TestGitHubReporter(t *testing.T) {
+	ds := diagnostic.NewDiagnosticSet()
+	ds.Add(diagnostic.Diagnostic{
+		File: "rules/order.grl",
+		Range: diagnostic.Range{
+			Start: diagnostic.Position{Line: 4, Column: 5},
+			End:   diagnostic.Position{Line: 4, Column: 9},
+		},
+		RuleID:   "GRL004",
+		RuleName: "missing-retract",
+		Severity: diagnostic.SeverityWarning,
+		Message:  "100% sure: no Retract()",
+	})
+	ds.Add(diagnostic.Diagnostic{
+		File:     "rules/order.grl",
+		Range:    diagnostic.Range{Start: diagnostic.Position{Line: 1, Column: 1}},
+		RuleID:   "GRL003",
+		RuleName: "missing-salience",
+		Severity: diagnostic.SeverityInfo,
+		Message:  "no salience",
+	})
+
+	var buf bytes.Buffer
+	if err := NewGitHubReporter(&buf).Report(ds); err != nil {
+		t.Fatalf("Report failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d: %q", len(lines), buf.String())
+	}
+
+	expected := "::notice file=rules/order.grl,line=1,col=1,title=GRL003 missing-salience::no salience"
+	if lines[0] != expected {
+		t.Errorf("got  %q\nwant %q", lines[0], expected)
+	}
+
+	expected = "::warning file=rules/order.grl,line=4,col=5,endLine=4,endColumn=9,title=GRL004 missing-retract::100%25 sure: no Retract()"
+	if lines[1] != expected {
+		t.Errorf("got  %q\nwant %q", lines[1], expected)
+	}
+}
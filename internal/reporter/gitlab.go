@@ -0,0 +1,108 @@
+package reporter
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/adarshjos/grule-lint/internal/diagnostic"
+)
+
+// GitLabReporter formats diagnostics as a GitLab Code Quality report.
+type GitLabReporter struct {
+	writer io.Writer
+}
+
+// NewGitLabReporter creates a new GitLabReporter.
+func NewGitLabReporter(w io.Writer) *GitLabReporter {
+	return &GitLabReporter{
+		writer: w,
+	}
+}
+
+type gitlabIssue struct {
+	Description string         `json:"description"`
+	CheckName   string         `json:"check_name"`
+	Fingerprint string         `json:"fingerprint"`
+	Severity    string         `json:"severity"`
+	Location    gitlabLocation `json:"location"`
+}
+
+type gitlabLocation struct {
+	Path  string      `json:"path"`
+	Lines gitlabLines `json:"lines"`
+}
+
+type gitlabLines struct {
+	Begin int `json:"begin"`
+	End   int `json:"end"`
+}
+
+// Format returns the output format name.
+func (r *GitLabReporter) Format() string {
+	return "gitlab"
+}
+
+// Report outputs diagnostics as a GitLab Code Quality JSON array.
+func (r *GitLabReporter) Report(diagnostics *diagnostic.DiagnosticSet) error {
+	issues := make([]gitlabIssue, 0, diagnostics.Count())
+	seen := make(map[string]int)
+
+	for _, d := range diagnostics.Sorted() {
+		end := d.Range.End.Line
+		if end < d.Range.Start.Line {
+			end = d.Range.Start.Line
+		}
+
+		issues = append(issues, gitlabIssue{
+			Description: d.Message,
+			CheckName:   d.RuleID,
+			Fingerprint: gitlabFingerprint(d, seen),
+			Severity:    gitlabSeverity(d.Severity),
+			Location: gitlabLocation{
+				Path:  filepath.ToSlash(d.File),
+				Lines: gitlabLines{Begin: d.Range.Start.Line, End: end},
+			},
+		})
+	}
+
+	encoder := json.NewEncoder(r.writer)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(issues); err != nil {
+		return fmt.Errorf("writing GitLab Code Quality report: %w", err)
+	}
+
+	return nil
+}
+
+// gitlabFingerprint hashes the file, rule and message of a diagnostic.
+// Line numbers are deliberately left out so the fingerprint survives edits
+// elsewhere in the file. Repeated identical findings in one file are told
+// apart by their occurrence count, tracked in seen.
+func gitlabFingerprint(d diagnostic.Diagnostic, seen map[string]int) string {
+	key := filepath.ToSlash(d.File) + "\x00" + d.RuleID + "\x00" + d.RuleName + "\x00" + d.Message
+	if n := seen[key]; n > 0 {
+		seen[key]++
+		key = fmt.Sprintf("%s\x00%d", key, n)
+	} else {
+		seen[key] = 1
+	}
+
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// gitlabSeverity maps a severity to a Code Quality severity.
+func gitlabSeverity(s diagnostic.Severity) string {
+	switch s {
+	case diagnostic.SeverityError:
+		return "major"
+	case diagnostic.SeverityWarning:
+		return "minor"
+	default:
+		return "info"
+	}
+}
@@ -0,0 +1,112 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/adarshjos/grule-lint/internal/diagnostic"
+)
+
+func TestGitLabReporter(t *testing.T) {
+	newSet := func(line int) *diagnostic.DiagnosticSet {
+		ds := diagnostic.NewDiagnosticSet()
+		for i := 0; i < 2; i++ {
+			ds.Add(diagnostic.Diagnostic{
+				File:     "rules/order.grl",
+				Range:    diagnostic.Range{Start: diagnostic.Position{Line: line + i, Column: 1}},
+				RuleID:   "GRL002",
+				RuleName: "missing-description",
+				Severity: diagnostic.SeverityWarning,
+				Message:  "Rule 'Foo' is missing a description",
+			})
+		}
+		return ds
+	}
+
+	report := func(ds *diagnostic.DiagnosticSet) []gitlabIssue {
+		var buf bytes.Buffer
+		if err := NewGitLabReporter(&buf).Report(ds); err != nil {
+			t.Fatalf("Report failed: %v", err)
+		}
+		var issues []gitlabIssue
+		if err := json.Unmarshal(buf.Bytes(), &issues); err != nil {
+			t.Fatalf("invalid JSON output: %v", err)
+		}
+		return issues
+	}
+
+	first := report(newSet(3))
+	if len(first) != 2 {
+		t.Fatalf("expected 2 issues, got %d", len(first))
+	}
+	if first[0].Severity != "minor" || first[0].CheckName != "GRL002" || first[0].Location.Lines.Begin != 3 {
+		t.Errorf("unexpected issue: %+v", first[0])
+	}
+	if first[0].Fingerprint == first[1].Fingerprint {
+		t.Error("repeated findings must have distinct fingerprints")
+	}
+
+	moved := report(newSet(10))
+	for i := range first {
+		if first[i].Fingerprint != moved[i].Fingerprint {
+			t.Errorf("fingerprint %d changed when the finding moved lines", i)
+		}
+	}
+}
+
+func TestRDJSONReporter(t *testing.T) {
+	ds := diagnostic.NewDiagnosticSet()
+	ds.Add(diagnostic.Diagnostic{
+		File: "rules/order.grl",
+		Range: diagnostic.Range{
+			Start: diagnostic.Position{Line: 5, Column: 17},
+			End:   diagnostic.Position{Line: 5, Column: 24},
+		},
+		RuleID:   "GRL007",
+		RuleName: "naming-convention",
+		Severity: diagnostic.SeverityError,
+		Message:  "bad name",
+		Fixes: []diagnostic.Fix{{
+			Description: "Rename",
+			Edits: []diagnostic.Edit{{
+				Range: diagnostic.Range{
+					Start: diagnostic.Position{Line: 5, Column: 17},
+					End:   diagnostic.Position{Line: 5, Column: 24},
+				},
+				NewText: "GoodName",
+			}},
+		}, {
+			Description: "Rename differently",
+			Edits: []diagnostic.Edit{{
+				Range: diagnostic.Range{
+					Start: diagnostic.Position{Line: 5, Column: 17},
+					End:   diagnostic.Position{Line: 5, Column: 24},
+				},
+				NewText: "OtherName",
+			}},
+		}},
+	})
+
+	var buf bytes.Buffer
+	if err := NewRDJSONReporter(&buf).Report(ds); err != nil {
+		t.Fatalf("Report failed: %v", err)
+	}
+
+	var result rdjsonResult
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("invalid JSON output: %v", err)
+	}
+
+	if result.Source.Name != "grule-lint" || len(result.Diagnostics) != 1 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	d := result.Diagnostics[0]
+	if d.Severity != "ERROR" || d.Code.Value != "GRL007" {
+		t.Errorf("unexpected diagnostic: %+v", d)
+	}
+	if len(d.Suggestions) != 1 || d.Suggestions[0].Text != "GoodName" || d.Suggestions[0].Range.End.Column != 24 {
+		t.Errorf("unexpected suggestions: %+v", d.Suggestions)
+	}
+}
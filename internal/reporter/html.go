@@ -0,0 +1,382 @@
+package reporter
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/adarshjos/grule-lint/internal/diagnostic"
+)
+
+// HTMLReporter formats diagnostics as a single self-contained HTML page
+// with a summary, a file table, per-file source views and the findings of
+// each rule.
+type HTMLReporter struct {
+	writer  io.Writer
+	sources SourceLookup
+}
+
+// NewHTMLReporter creates a new HTMLReporter. Sources is used to embed file
+// contents; files it cannot resolve are listed without a source view.
+func NewHTMLReporter(w io.Writer, sources SourceLookup) *HTMLReporter {
+	return &HTMLReporter{
+		writer:  w,
+		sources: sources,
+	}
+}
+
+type htmlReport struct {
+	Title      string
+	Summary    TemplateSummary
+	Severities []htmlCount
+	Rules      []htmlRule
+	Files      []htmlFile
+}
+
+type htmlCount struct {
+	Name  string
+	Count int
+}
+
+// htmlRule lists the findings of a rule, counted by their reported
+// severity, which path-specific overrides may change.
+type htmlRule struct {
+	Anchor   string
+	ID       string
+	Name     string
+	Errors   int
+	Warnings int
+	Others   int
+	Findings []htmlFinding
+}
+
+// htmlFinding is an entry of a rule's findings, linking to the diagnostic.
+type htmlFinding struct {
+	Target   string
+	File     string
+	Line     int
+	Column   int
+	Severity string
+	Message  string
+}
+
+type htmlFile struct {
+	Anchor      string
+	Name        string
+	Errors      int
+	Warnings    int
+	Others      int
+	Diagnostics []htmlDiagnostic
+	Lines       []htmlLine
+}
+
+type htmlDiagnostic struct {
+	Anchor   string
+	Line     int
+	Column   int
+	RuleID   string
+	RuleName string
+	Severity string
+	Message  string
+	Fixes    []string
+}
+
+type htmlLine struct {
+	Number      int
+	Segments    []htmlSegment
+	Diagnostics []htmlDiagnostic
+}
+
+type htmlSegment struct {
+	Text     string
+	Severity string
+}
+
+// Format returns the output format name.
+func (r *HTMLReporter) Format() string {
+	return "html"
+}
+
+// Report outputs diagnostics as an HTML page.
+func (r *HTMLReporter) Report(diagnostics *diagnostic.DiagnosticSet) error {
+	data := TemplateData{}
+	if diagnostics != nil {
+		data = NewTemplateData(diagnostics)
+	}
+
+	report := htmlReport{
+		Title:   "grule-lint report",
+		Summary: data.Summary,
+		Severities: []htmlCount{
+			{Name: "error", Count: data.Summary.Errors},
+			{Name: "warning", Count: data.Summary.Warnings},
+			{Name: "info", Count: data.Summary.Infos},
+			{Name: "hint", Count: data.Summary.Hints},
+		},
+	}
+
+	for i, f := range data.Files {
+		report.Files = append(report.Files, r.buildFile(i, f))
+	}
+	report.Rules = htmlRules(report.Files)
+
+	if err := htmlTemplate.Execute(r.writer, report); err != nil {
+		return fmt.Errorf("writing HTML report: %w", err)
+	}
+	return nil
+}
+
+// htmlRules groups the diagnostics of the files by rule, ordered by rule
+// ID. Findings link to their annotation in the source view, or to the file
+// section if the source is not available.
+func htmlRules(files []htmlFile) []htmlRule {
+	byRule := make(map[string]*htmlRule)
+	for _, f := range files {
+		for _, d := range f.Diagnostics {
+			rule, ok := byRule[d.RuleID]
+			if !ok {
+				rule = &htmlRule{Anchor: "rule-" + d.RuleID, ID: d.RuleID, Name: d.RuleName}
+				byRule[d.RuleID] = rule
+			}
+
+			target := f.Anchor
+			if f.Lines != nil {
+				target = d.Anchor
+			}
+			rule.Findings = append(rule.Findings, htmlFinding{
+				Target:   target,
+				File:     f.Name,
+				Line:     d.Line,
+				Column:   d.Column,
+				Severity: d.Severity,
+				Message:  d.Message,
+			})
+
+			switch d.Severity {
+			case diagnostic.SeverityError.String():
+				rule.Errors++
+			case diagnostic.SeverityWarning.String():
+				rule.Warnings++
+			default:
+				rule.Others++
+			}
+		}
+	}
+
+	rules := make([]htmlRule, 0, len(byRule))
+	for _, rule := range byRule {
+		rules = append(rules, *rule)
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].ID < rules[j].ID
+	})
+	return rules
+}
+
+// buildFile prepares the per-file view, including highlighted source lines.
+func (r *HTMLReporter) buildFile(index int, f TemplateFile) htmlFile {
+	file := htmlFile{
+		Anchor: fmt.Sprintf("file-%d", index+1),
+		Name:   f.Name,
+	}
+
+	byLine := make(map[int][]htmlDiagnostic)
+	for i, d := range f.Diagnostics {
+		hd := htmlDiagnostic{
+			Anchor:   fmt.Sprintf("%s-d%d", file.Anchor, i+1),
+			Line:     d.Range.Start.Line,
+			Column:   d.Range.Start.Column,
+			RuleID:   d.RuleID,
+			RuleName: d.RuleName,
+			Severity: d.Severity.String(),
+			Message:  d.Message,
+		}
+		for _, fix := range d.Fixes {
+			hd.Fixes = append(hd.Fixes, fix.Description)
+		}
+		file.Diagnostics = append(file.Diagnostics, hd)
+		byLine[hd.Line] = append(byLine[hd.Line], hd)
+
+		switch d.Severity {
+		case diagnostic.SeverityError:
+			file.Errors++
+		case diagnostic.SeverityWarning:
+			file.Warnings++
+		default:
+			file.Others++
+		}
+	}
+
+	if r.sources == nil {
+		return file
+	}
+	content, ok := r.sources(f.Name)
+	if !ok {
+		return file
+	}
+
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	marks := highlightMarks(lines, f.Diagnostics)
+	for i, line := range lines {
+		file.Lines = append(file.Lines, htmlLine{
+			Number:      i + 1,
+			Segments:    lineSegments(line, marks[i]),
+			Diagnostics: byLine[i+1],
+		})
+	}
+
+	return file
+}
+
+// highlightMarks computes, per line and rune, the most severe diagnostic
+// covering that character. A value of -1 means no diagnostic.
+func highlightMarks(lines []string, diags []diagnostic.Diagnostic) [][]diagnostic.Severity {
+	marks := make([][]diagnostic.Severity, len(lines))
+
+	for _, d := range diags {
+		start, end := d.Range.Start, d.Range.End
+		if end.Line < start.Line || (end.Line == start.Line && end.Column < start.Column) {
+			end = start
+		}
+
+		for n := start.Line; n <= end.Line && n <= len(lines); n++ {
+			if n < 1 {
+				continue
+			}
+			line := lines[n-1]
+			from, to := underlineSpan(line, n, start, end)
+
+			runes := []rune(line)
+			if marks[n-1] == nil {
+				marks[n-1] = make([]diagnostic.Severity, len(runes)+1)
+				for i := range marks[n-1] {
+					marks[n-1][i] = -1
+				}
+			}
+			for col := from; col < to && col-1 < len(marks[n-1]); col++ {
+				current := marks[n-1][col-1]
+				if current < 0 || d.Severity < current {
+					marks[n-1][col-1] = d.Severity
+				}
+			}
+		}
+	}
+
+	return marks
+}
+
+// lineSegments splits a line into runs of equally highlighted text.
+func lineSegments(line string, marks []diagnostic.Severity) []htmlSegment {
+	runes := []rune(line)
+	if marks == nil {
+		return []htmlSegment{{Text: line}}
+	}
+
+	var segments []htmlSegment
+	var current strings.Builder
+	currentSev := diagnostic.Severity(-1)
+
+	flush := func() {
+		if current.Len() == 0 {
+			return
+		}
+		seg := htmlSegment{Text: current.String()}
+		if currentSev >= 0 {
+			seg.Severity = currentSev.String()
+		}
+		segments = append(segments, seg)
+		current.Reset()
+	}
+
+	for i := 0; i <= len(runes); i++ {
+		sev := marks[i]
+		ch := " "
+		if i < len(runes) {
+			ch = string(runes[i])
+		} else if sev < 0 {
+			break
+		}
+
+		if sev != currentSev {
+			flush()
+			currentSev = sev
+		}
+		current.WriteString(ch)
+	}
+	flush()
+
+	return segments
+}
+
+var htmlTemplate = template.Must(template.New("html").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2rem; color: #1f2328; }
+h1, h2, h3 { font-weight: 600; }
+table { border-collapse: collapse; margin-bottom: 1.5rem; }
+th, td { border: 1px solid #d0d7de; padding: 0.3rem 0.7rem; text-align: left; }
+th { background: #f6f8fa; }
+td.num { text-align: right; }
+.badge { display: inline-block; padding: 0 0.5rem; border-radius: 1rem; font-size: 0.85em; color: #fff; }
+.badge.error { background: #cf222e; } .badge.warning { background: #bf8700; }
+.badge.info { background: #0969da; } .badge.hint { background: #6e7781; }
+.source { font-family: ui-monospace, Menlo, Consolas, monospace; font-size: 0.85em; background: #f6f8fa; border: 1px solid #d0d7de; padding: 0.5rem 0; overflow-x: auto; }
+.source .line { white-space: pre; }
+.source .ln { display: inline-block; width: 4em; padding-right: 1em; text-align: right; color: #6e7781; user-select: none; }
+.hl.error { background: #ffebe9; text-decoration: underline wavy #cf222e; }
+.hl.warning { background: #fff8c5; text-decoration: underline wavy #bf8700; }
+.hl.info, .hl.hint { background: #ddf4ff; text-decoration: underline dotted #0969da; }
+.annotation { white-space: normal; margin: 0.2rem 0 0.4rem 5em; font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; }
+.fix { color: #1a7f37; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+
+<h2>Summary</h2>
+<p>{{.Summary.Total}} issue(s) in {{len .Files}} file(s).</p>
+<table>
+<tr><th>Severity</th><th>Count</th></tr>
+{{range .Severities}}<tr><td><span class="badge {{.Name}}">{{.Name}}</span></td><td class="num">{{.Count}}</td></tr>
+{{end}}</table>
+
+{{if .Rules}}<table>
+<tr><th>Rule</th><th>Name</th><th>Errors</th><th>Warnings</th><th>Other</th></tr>
+{{range .Rules}}<tr><td><a href="#{{.Anchor}}">{{.ID}}</a></td><td>{{.Name}}</td><td class="num">{{.Errors}}</td><td class="num">{{.Warnings}}</td><td class="num">{{.Others}}</td></tr>
+{{end}}</table>{{end}}
+
+<h2>Files</h2>
+{{if .Files}}<table>
+<tr><th>File</th><th>Errors</th><th>Warnings</th><th>Other</th></tr>
+{{range .Files}}<tr><td><a href="#{{.Anchor}}">{{.Name}}</a></td><td class="num">{{.Errors}}</td><td class="num">{{.Warnings}}</td><td class="num">{{.Others}}</td></tr>
+{{end}}</table>{{else}}<p>No issues found.</p>{{end}}
+
+{{range .Files}}
+<section id="{{.Anchor}}">
+<h3>{{.Name}}</h3>
+<ul>
+{{range .Diagnostics}}<li><a href="#{{.Anchor}}">{{.Line}}:{{.Column}}</a> <span class="badge {{.Severity}}">{{.Severity}}</span> <strong>{{.RuleID}}</strong> {{.RuleName}}: {{.Message}}</li>
+{{end}}</ul>
+{{if .Lines}}<div class="source">
+{{range .Lines}}<div class="line"><span class="ln">{{.Number}}</span>{{range .Segments}}{{if .Severity}}<span class="hl {{.Severity}}">{{.Text}}</span>{{else}}{{.Text}}{{end}}{{end}}</div>
+{{range .Diagnostics}}<div class="annotation" id="{{.Anchor}}"><span class="badge {{.Severity}}">{{.Severity}}</span> <strong>{{.RuleID}}</strong> {{.RuleName}}: {{.Message}}{{range .Fixes}}<br><span class="fix">Suggested fix: {{.}}</span>{{end}}</div>
+{{end}}{{end}}</div>{{end}}
+</section>
+{{end}}
+{{if .Rules}}<h2>Rules</h2>
+{{range .Rules}}
+<section id="{{.Anchor}}">
+<h3>{{.ID}} {{.Name}}</h3>
+<ul>
+{{range .Findings}}<li><a href="#{{.Target}}">{{.File}}:{{.Line}}:{{.Column}}</a> <span class="badge {{.Severity}}">{{.Severity}}</span> {{.Message}}</li>
+{{end}}</ul>
+</section>
+{{end}}{{end}}
+</body>
+</html>
+`))
@@ -0,0 +1,105 @@
+package reporter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/adarshjos/grule-lint/internal/diagnostic"
+)
+
+func TestHTMLReporter(t *testing.T) {
+	ds := diagnostic.NewDiagnosticSet()
+	ds.Add(diagnostic.Diagnostic{
+		File: "test.grl",
+		Range: diagnostic.Range{
+			Start: diagnostic.Position{Line: 5, Column: 17},
+			End:   diagnostic.Position{Line: 5, Column: 23},
+		},
+		RuleID:   "GRL004",
+		RuleName: "missing-retract",
+		Severity: diagnostic.SeverityWarning,
+		Message:  "Rule <Foo> retracts the wrong rule",
+		Fixes:    []diagnostic.Fix{{Description: `Retract "Foo" instead`}},
+	})
+
+	sources := func(file string) (string, bool) {
+		return prettySource, file == "test.grl"
+	}
+
+	var buf bytes.Buffer
+	if err := NewHTMLReporter(&buf, sources).Report(ds); err != nil {
+		t.Fatalf("Report failed: %v", err)
+	}
+
+	output := buf.String()
+	expected := []string{
+		"<!DOCTYPE html>",
+		"1 issue(s) in 1 file(s).",
+		`<a href="#file-1">test.grl</a>`,
+		`<td><a href="#rule-GRL004">GRL004</a></td><td>missing-retract</td>`,
+		`<section id="rule-GRL004">`,
+		`<a href="#file-1-d1">test.grl:5:17</a>`,
+		`<span class="hl warning">&#34;Fooo&#34;</span>`,
+		"Rule &lt;Foo&gt; retracts the wrong rule",
+		"Suggested fix: Retract &#34;Foo&#34; instead",
+	}
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+	if strings.Contains(output, "<Foo>") {
+		t.Error("diagnostic message must be HTML-escaped")
+	}
+}
+
+func TestHTMLReporterRuleSeverities(t *testing.T) {
+	ds := diagnostic.NewDiagnosticSet()
+	for _, d := range []struct {
+		file     string
+		severity diagnostic.Severity
+	}{
+		{"a.grl", diagnostic.SeverityWarning},
+		{"b.grl", diagnostic.SeverityError},
+		{"b.grl", diagnostic.SeverityWarning},
+	} {
+		ds.Add(diagnostic.Diagnostic{
+			File:     d.file,
+			Range:    diagnostic.Range{Start: diagnostic.Position{Line: 1, Column: 1}},
+			RuleID:   "GRL004",
+			RuleName: "missing-retract",
+			Severity: d.severity,
+			Message:  "Rule does not call Retract()",
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := NewHTMLReporter(&buf, nil).Report(ds); err != nil {
+		t.Fatalf("Report failed: %v", err)
+	}
+
+	// Severities are counted as reported, e.g. after per-path overrides,
+	// and findings without a source view link to their file
+	output := buf.String()
+	expected := []string{
+		`<td>missing-retract</td><td class="num">1</td><td class="num">2</td><td class="num">0</td>`,
+		`<a href="#file-1">a.grl:1:1</a>`,
+		`<a href="#file-2">b.grl:1:1</a> <span class="badge error">error</span>`,
+	}
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+}
+
+func TestHTMLReporterEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewHTMLReporter(&buf, nil).Report(diagnostic.NewDiagnosticSet()); err != nil {
+		t.Fatalf("Report failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "No issues found.") {
+		t.Errorf("expected empty report message, got:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,133 @@
+package reporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/adarshjos/grule-lint/internal/diagnostic"
+)
+
+// JSONReporter formats diagnostics as a single JSON document.
+type JSONReporter struct {
+	writer io.Writer
+}
+
+// NewJSONReporter creates a new JSONReporter.
+func NewJSONReporter(w io.Writer) *JSONReporter {
+	return &JSONReporter{
+		writer: w,
+	}
+}
+
+type jsonReport struct {
+	Diagnostics []jsonDiagnostic `json:"diagnostics"`
+	Summary     jsonSummary      `json:"summary"`
+}
+
+type jsonDiagnostic struct {
+	File     string    `json:"file"`
+	Range    jsonRange `json:"range"`
+	RuleID   string    `json:"ruleId"`
+	RuleName string    `json:"ruleName"`
+	Severity string    `json:"severity"`
+	Message  string    `json:"message"`
+	Fixes    []jsonFix `json:"fixes,omitempty"`
+}
+
+type jsonRange struct {
+	Start jsonPosition `json:"start"`
+	End   jsonPosition `json:"end"`
+}
+
+type jsonPosition struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+type jsonFix struct {
+	Description string     `json:"description"`
+	Edits       []jsonEdit `json:"edits"`
+}
+
+type jsonEdit struct {
+	Range   jsonRange `json:"range"`
+	NewText string    `json:"newText"`
+}
+
+type jsonSummary struct {
+	Total    int `json:"total"`
+	Errors   int `json:"errors"`
+	Warnings int `json:"warnings"`
+	Infos    int `json:"infos"`
+	Hints    int `json:"hints"`
+}
+
+// Format returns the output format name.
+func (r *JSONReporter) Format() string {
+	return "json"
+}
+
+// Report outputs diagnostics in JSON format.
+func (r *JSONReporter) Report(diagnostics *diagnostic.DiagnosticSet) error {
+	sorted := diagnostics.Sorted()
+	counts := diagnostics.CountBySeverity()
+
+	report := jsonReport{
+		Diagnostics: make([]jsonDiagnostic, 0, len(sorted)),
+		Summary: jsonSummary{
+			Total:    diagnostics.Count(),
+			Errors:   counts[diagnostic.SeverityError],
+			Warnings: counts[diagnostic.SeverityWarning],
+			Infos:    counts[diagnostic.SeverityInfo],
+			Hints:    counts[diagnostic.SeverityHint],
+		},
+	}
+
+	for _, d := range sorted {
+		report.Diagnostics = append(report.Diagnostics, toJSONDiagnostic(d))
+	}
+
+	encoder := json.NewEncoder(r.writer)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return fmt.Errorf("writing JSON report: %w", err)
+	}
+
+	return nil
+}
+
+// toJSONDiagnostic converts a diagnostic to its JSON representation.
+func toJSONDiagnostic(d diagnostic.Diagnostic) jsonDiagnostic {
+	jd := jsonDiagnostic{
+		File:     d.File,
+		Range:    toJSONRange(d.Range),
+		RuleID:   d.RuleID,
+		RuleName: d.RuleName,
+		Severity: d.Severity.String(),
+		Message:  d.Message,
+	}
+
+	for _, fix := range d.Fixes {
+		jf := jsonFix{
+			Description: fix.Description,
+			Edits:       make([]jsonEdit, 0, len(fix.Edits)),
+		}
+		for _, edit := range fix.Edits {
+			jf.Edits = append(jf.Edits, jsonEdit{
+				Range:   toJSONRange(edit.Range),
+				NewText: edit.NewText,
+			})
+		}
+		jd.Fixes = append(jd.Fixes, jf)
+	}
+
+	return jd
+}
+
+func toJSONRange(rng diagnostic.Range) jsonRange {
+	return jsonRange{
+		Start: jsonPosition{Line: rng.Start.Line, Column: rng.Start.Column},
+		End:   jsonPosition{Line: rng.End.Line, Column: rng.End.Column},
+	}
+}
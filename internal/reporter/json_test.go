@@ -0,0 +1,73 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/adarshjos/grule-lint/internal/diagnostic"
+)
+
+func TestJSONReporter(t *testing.T) {
+	ds := diagnostic.NewDiagnosticSet()
+	ds.Add(diagnostic.Diagnostic{
+		File: "test.grl",
+		Range: diagnostic.Range{
+			Start: diagnostic.Position{Line: 3, Column: 5},
+			End:   diagnostic.Position{Line: 3, Column: 9},
+		},
+		RuleID:   "GRL004",
+		RuleName: "missing-retract",
+		Severity: diagnostic.SeverityWarning,
+		Message:  "Rule 'Foo' does not call Retract()",
+		Fixes: []diagnostic.Fix{{
+			Description: "Add Retract call",
+			Edits: []diagnostic.Edit{{
+				Range:   diagnostic.Range{Start: diagnostic.Position{Line: 4, Column: 1}, End: diagnostic.Position{Line: 4, Column: 1}},
+				NewText: `Retract("Foo");`,
+			}},
+		}},
+	})
+
+	var buf bytes.Buffer
+	if err := NewJSONReporter(&buf).Report(ds); err != nil {
+		t.Fatalf("Report failed: %v", err)
+	}
+
+	var report jsonReport
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, buf.String())
+	}
+
+	if len(report.Diagnostics) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %d", len(report.Diagnostics))
+	}
+	d := report.Diagnostics[0]
+	if d.Severity != "warning" || d.RuleID != "GRL004" || d.RuleName != "missing-retract" {
+		t.Errorf("unexpected diagnostic: %+v", d)
+	}
+	if d.Range.End.Column != 9 {
+		t.Errorf("expected end column 9, got %d", d.Range.End.Column)
+	}
+	if len(d.Fixes) != 1 || len(d.Fixes[0].Edits) != 1 {
+		t.Errorf("expected fix with one edit, got %+v", d.Fixes)
+	}
+	if report.Summary.Total != 1 || report.Summary.Warnings != 1 {
+		t.Errorf("unexpected summary: %+v", report.Summary)
+	}
+}
+
+func TestJSONReporterEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewJSONReporter(&buf).Report(diagnostic.NewDiagnosticSet()); err != nil {
+		t.Fatalf("Report failed: %v", err)
+	}
+
+	var report jsonReport
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("invalid JSON output: %v", err)
+	}
+	if report.Diagnostics == nil || len(report.Diagnostics) != 0 {
+		t.Errorf("expected empty diagnostics array, got %v", report.Diagnostics)
+	}
+}
@@ -0,0 +1,105 @@
+package reporter
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+
+	"github.com/adarshjos/grule-lint/internal/diagnostic"
+)
+
+// JUnitReporter formats diagnostics as JUnit XML. Each linted file becomes
+// a test suite and each rule violation a failed test case. Files without
+// violations get a single passing test case.
+type JUnitReporter struct {
+	writer io.Writer
+}
+
+// NewJUnitReporter creates a new JUnitReporter.
+func NewJUnitReporter(w io.Writer) *JUnitReporter {
+	return &JUnitReporter{
+		writer: w,
+	}
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// Format returns the output format name.
+func (r *JUnitReporter) Format() string {
+	return "junit"
+}
+
+// Report outputs diagnostics as JUnit XML.
+func (r *JUnitReporter) Report(diagnostics *diagnostic.DiagnosticSet) error {
+	report := junitTestSuites{Name: toolName}
+
+	byFile := make(map[string][]diagnostic.Diagnostic)
+	for _, d := range diagnostics.Sorted() {
+		byFile[d.File] = append(byFile[d.File], d)
+	}
+	files := diagnostics.Files()
+	for file := range byFile {
+		if !slices.Contains(files, file) {
+			files = append(files, file)
+		}
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		suite := junitTestSuite{Name: file}
+		if len(byFile[file]) == 0 {
+			suite.TestCases = append(suite.TestCases, junitTestCase{Name: toolName, ClassName: file})
+			suite.Tests++
+			report.Tests++
+		}
+		for _, d := range byFile[file] {
+			suite.TestCases = append(suite.TestCases, failedTestCase(d))
+			suite.Tests++
+			suite.Failures++
+			report.Tests++
+			report.Failures++
+		}
+		report.Suites = append(report.Suites, suite)
+	}
+
+	return writeXML(r.writer, report)
+}
+
+func failedTestCase(d diagnostic.Diagnostic) junitTestCase {
+	return junitTestCase{
+		Name:      fmt.Sprintf("%s %s at %s", d.RuleID, d.RuleName, d.Range.Start),
+		ClassName: d.File,
+		Failure: &junitFailure{
+			Message: d.Message,
+			Type:    d.Severity.String(),
+			Text:    fmt.Sprintf("%s:%s: %s [%s] %s", d.File, d.Range.Start, d.RuleID, d.Severity, d.Message),
+		},
+	}
+}
@@ -0,0 +1,228 @@
+package reporter
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/adarshjos/grule-lint/internal/diagnostic"
+)
+
+// SourceLookup returns the content of a linted file, or false if the
+// content is not available.
+type SourceLookup func(file string) (string, bool)
+
+// maxFrameLines limits how many lines of a multi-line range are rendered.
+const maxFrameLines = 5
+
+// PrettyReporter formats diagnostics as text with a code frame showing the
+// offending GRL source.
+type PrettyReporter struct {
+	text    *TextReporter
+	sources SourceLookup
+}
+
+// NewPrettyReporter creates a new PrettyReporter. Sources is used to look
+// up file contents; diagnostics for files it cannot resolve are printed
+// without a code frame.
+func NewPrettyReporter(w io.Writer, colorized bool, sources SourceLookup) *PrettyReporter {
+	return &PrettyReporter{
+		text:    NewTextReporter(w, colorized),
+		sources: sources,
+	}
+}
+
+// Format returns the output format name.
+func (r *PrettyReporter) Format() string {
+	return "pretty"
+}
+
+// Report outputs diagnostics with source code frames.
+func (r *PrettyReporter) Report(diagnostics *diagnostic.DiagnosticSet) error {
+	lineCache := make(map[string][]string)
+
+	for _, d := range diagnostics.Sorted() {
+		lines, ok := lineCache[d.File]
+		if !ok {
+			lines = r.sourceLines(d.File)
+			lineCache[d.File] = lines
+		}
+
+		if _, err := io.WriteString(r.text.writer, r.formatDiagnostic(d, lines)); err != nil {
+			return fmt.Errorf("writing diagnostic: %w", err)
+		}
+	}
+
+	if diagnostics.Count() > 0 {
+		if _, err := fmt.Fprintln(r.text.writer, r.text.formatSummary(diagnostics)); err != nil {
+			return fmt.Errorf("writing summary: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// sourceLines returns the lines of a file, or nil if the source is unknown.
+func (r *PrettyReporter) sourceLines(file string) []string {
+	if r.sources == nil {
+		return nil
+	}
+	content, ok := r.sources(file)
+	if !ok {
+		return nil
+	}
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	return strings.Split(content, "\n")
+}
+
+// formatDiagnostic renders a diagnostic header, code frame and fix hints.
+func (r *PrettyReporter) formatDiagnostic(d diagnostic.Diagnostic, lines []string) string {
+	var sb strings.Builder
+
+	// Header: severity[CODE]: message
+	header := fmt.Sprintf("%s[%s]", d.Severity.String(), d.RuleID)
+	if r.text.colorized {
+		header = colorBold + r.text.colorSeverity(d.Severity, header) + colorReset
+	}
+	fmt.Fprintf(&sb, "%s: %s\n", header, d.Message)
+
+	start, end := d.Range.Start, d.Range.End
+	if end.Line < start.Line || (end.Line == start.Line && end.Column < start.Column) {
+		end = start
+	}
+
+	if start.Line < 1 || start.Line > len(lines) {
+		fmt.Fprintf(&sb, "  --> %s:%s\n\n", d.File, start)
+		return sb.String()
+	}
+
+	lastLine := end.Line
+	if lastLine > len(lines) {
+		lastLine = len(lines)
+	}
+	if lastLine-start.Line >= maxFrameLines {
+		lastLine = start.Line + maxFrameLines - 1
+	}
+	firstLine := start.Line - 1
+	if firstLine < 1 {
+		firstLine = 1
+	}
+
+	width := len(strconv.Itoa(lastLine))
+	gutter := strings.Repeat(" ", width) + " |"
+	if r.text.colorized {
+		gutter = colorGray + gutter + colorReset
+	}
+
+	fmt.Fprintf(&sb, "%s--> %s:%s\n", strings.Repeat(" ", width), d.File, start)
+	fmt.Fprintln(&sb, gutter)
+
+	for n := firstLine; n <= lastLine; n++ {
+		line := lines[n-1]
+		number := fmt.Sprintf("%*d |", width, n)
+		if r.text.colorized {
+			number = colorGray + number + colorReset
+		}
+		fmt.Fprintf(&sb, "%s %s\n", number, line)
+
+		if n < start.Line {
+			continue
+		}
+
+		fromCol, toCol := underlineSpan(line, n, start, end)
+		if toCol <= fromCol {
+			continue
+		}
+
+		marker := caretPadding(line, fromCol) + strings.Repeat("^", toCol-fromCol)
+		if n == lastLine && d.RuleName != "" {
+			marker += " " + d.RuleName
+		}
+		if r.text.colorized {
+			marker = r.text.colorSeverity(d.Severity, marker)
+		}
+		fmt.Fprintf(&sb, "%s %s\n", gutter, marker)
+	}
+
+	for _, fix := range d.Fixes {
+		hint := fix.Description
+		if hint == "" && len(fix.Edits) > 0 {
+			hint = fmt.Sprintf("replace with %q", fix.Edits[0].NewText)
+		}
+		fmt.Fprintf(&sb, "%s = help: %s\n", strings.Repeat(" ", width), hint)
+	}
+
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// underlineSpan returns the 1-based [from, to) column span to underline on
+// line n of a range. A zero-width range underlines the word at its start.
+func underlineSpan(line string, n int, start, end diagnostic.Position) (int, int) {
+	runes := []rune(line)
+	lineEnd := len(runes) + 1
+
+	from := 1
+	if n == start.Line {
+		from = start.Column
+	} else {
+		// Skip indentation on continuation lines
+		for from < lineEnd && unicode.IsSpace(runes[from-1]) {
+			from++
+		}
+	}
+
+	to := lineEnd
+	if n == end.Line {
+		to = end.Column
+	}
+
+	if n == start.Line && n == end.Line && to <= from {
+		// Zero-width range: underline the identifier or character at start
+		to = from
+		for to < lineEnd && isWordRune(runes[to-1]) {
+			to++
+		}
+		if to == from {
+			to = from + 1
+		}
+	}
+
+	if from < 1 {
+		from = 1
+	}
+	if to > lineEnd {
+		to = lineEnd
+	}
+	if from >= lineEnd {
+		// Range starts at end of line: point just past the last character
+		return lineEnd, lineEnd + 1
+	}
+	return from, to
+}
+
+// caretPadding returns the whitespace needed to align a marker under the
+// given 1-based column, preserving tabs from the source line.
+func caretPadding(line string, column int) string {
+	var sb strings.Builder
+	for i, r := range []rune(line) {
+		if i >= column-1 {
+			break
+		}
+		if r == '\t' {
+			sb.WriteRune('\t')
+		} else {
+			sb.WriteRune(' ')
+		}
+	}
+	for i := len([]rune(line)); i < column-1; i++ {
+		sb.WriteRune(' ')
+	}
+	return sb.String()
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,102 @@
+package reporter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/adarshjos/grule-lint/internal/diagnostic"
+)
+
+const prettySource = `rule Foo "desc" salience 1 {
+    when
+        Order.Total > 10
+    then
+        Retract("Fooo");
+}`
+
+func TestPrettyReporter(t *testing.T) {
+	ds := diagnostic.NewDiagnosticSet()
+	ds.Add(diagnostic.Diagnostic{
+		File: "test.grl",
+		Range: diagnostic.Range{
+			Start: diagnostic.Position{Line: 5, Column: 17},
+			End:   diagnostic.Position{Line: 5, Column: 23},
+		},
+		RuleID:   "GRL004",
+		RuleName: "missing-retract",
+		Severity: diagnostic.SeverityWarning,
+		Message:  "wrong retract target",
+		Fixes: []diagnostic.Fix{{
+			Description: `Retract "Foo" instead`,
+			Edits:       []diagnostic.Edit{{NewText: `"Foo"`}},
+		}},
+	})
+
+	sources := func(file string) (string, bool) {
+		return prettySource, file == "test.grl"
+	}
+
+	var buf bytes.Buffer
+	if err := NewPrettyReporter(&buf, false, sources).Report(ds); err != nil {
+		t.Fatalf("Report failed: %v", err)
+	}
+
+	output := buf.String()
+	expected := []string{
+		"warning[GRL004]: wrong retract target",
+		"--> test.grl:5:17",
+		"4 |     then",
+		`5 |         Retract("Fooo");`,
+		"  |                 ^^^^^^ missing-retract",
+		`= help: Retract "Foo" instead`,
+		"Found 1 issue (1 warning)",
+	}
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestPrettyReporterZeroWidthRange(t *testing.T) {
+	ds := diagnostic.NewDiagnosticSet()
+	ds.Add(diagnostic.Diagnostic{
+		File:     "test.grl",
+		Range:    diagnostic.Range{Start: diagnostic.Position{Line: 4, Column: 5}, End: diagnostic.Position{Line: 4, Column: 5}},
+		RuleID:   "GRL004",
+		RuleName: "missing-retract",
+		Severity: diagnostic.SeverityWarning,
+		Message:  "no retract",
+	})
+
+	var buf bytes.Buffer
+	sources := func(string) (string, bool) { return prettySource, true }
+	if err := NewPrettyReporter(&buf, false, sources).Report(ds); err != nil {
+		t.Fatalf("Report failed: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "  |     ^^^^ missing-retract") {
+		t.Errorf("expected the 'then' keyword to be underlined, got:\n%s", buf.String())
+	}
+}
+
+func TestPrettyReporterMissingSource(t *testing.T) {
+	ds := diagnostic.NewDiagnosticSet()
+	ds.Add(diagnostic.Diagnostic{
+		File:     "gone.grl",
+		Range:    diagnostic.Range{Start: diagnostic.Position{Line: 2, Column: 1}},
+		RuleID:   "GRL002",
+		Severity: diagnostic.SeverityWarning,
+		Message:  "missing description",
+	})
+
+	var buf bytes.Buffer
+	if err := NewPrettyReporter(&buf, false, nil).Report(ds); err != nil {
+		t.Fatalf("Report failed: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "--> gone.grl:2:1") {
+		t.Errorf("expected location without code frame, got:\n%s", buf.String())
+	}
+}
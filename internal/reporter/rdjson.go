@@ -0,0 +1,132 @@
+package reporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/adarshjos/grule-lint/internal/diagnostic"
+)
+
+// RDJSONReporter formats diagnostics in reviewdog's rdjson format.
+type RDJSONReporter struct {
+	writer io.Writer
+}
+
+// NewRDJSONReporter creates a new RDJSONReporter.
+func NewRDJSONReporter(w io.Writer) *RDJSONReporter {
+	return &RDJSONReporter{
+		writer: w,
+	}
+}
+
+type rdjsonResult struct {
+	Source      rdjsonSource       `json:"source"`
+	Diagnostics []rdjsonDiagnostic `json:"diagnostics"`
+}
+
+type rdjsonSource struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+type rdjsonDiagnostic struct {
+	Message     string             `json:"message"`
+	Location    rdjsonLocation     `json:"location"`
+	Severity    string             `json:"severity"`
+	Code        rdjsonCode         `json:"code"`
+	Suggestions []rdjsonSuggestion `json:"suggestions,omitempty"`
+}
+
+type rdjsonLocation struct {
+	Path  string      `json:"path"`
+	Range rdjsonRange `json:"range"`
+}
+
+type rdjsonRange struct {
+	Start rdjsonPosition  `json:"start"`
+	End   *rdjsonPosition `json:"end,omitempty"`
+}
+
+type rdjsonPosition struct {
+	Line   int `json:"line"`
+	Column int `json:"column,omitempty"`
+}
+
+type rdjsonCode struct {
+	Value string `json:"value"`
+}
+
+type rdjsonSuggestion struct {
+	Range rdjsonRange `json:"range"`
+	Text  string      `json:"text"`
+}
+
+// Format returns the output format name.
+func (r *RDJSONReporter) Format() string {
+	return "rdjson"
+}
+
+// Report outputs diagnostics as a reviewdog rdjson document.
+func (r *RDJSONReporter) Report(diagnostics *diagnostic.DiagnosticSet) error {
+	result := rdjsonResult{
+		Source:      rdjsonSource{Name: toolName, URL: toolInfoURI},
+		Diagnostics: make([]rdjsonDiagnostic, 0, diagnostics.Count()),
+	}
+
+	for _, d := range diagnostics.Sorted() {
+		rd := rdjsonDiagnostic{
+			Message: d.Message,
+			Location: rdjsonLocation{
+				Path:  filepath.ToSlash(d.File),
+				Range: toRDJSONRange(d.Range),
+			},
+			Severity: rdjsonSeverity(d.Severity),
+			Code:     rdjsonCode{Value: d.RuleID},
+		}
+
+		// reviewdog applies all suggestions of a diagnostic together, so
+		// alternative fixes cannot be represented; only the first is sent
+		if len(d.Fixes) > 0 {
+			for _, edit := range d.Fixes[0].Edits {
+				rd.Suggestions = append(rd.Suggestions, rdjsonSuggestion{
+					Range: toRDJSONRange(edit.Range),
+					Text:  edit.NewText,
+				})
+			}
+		}
+
+		result.Diagnostics = append(result.Diagnostics, rd)
+	}
+
+	encoder := json.NewEncoder(r.writer)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		return fmt.Errorf("writing rdjson report: %w", err)
+	}
+
+	return nil
+}
+
+func toRDJSONRange(rng diagnostic.Range) rdjsonRange {
+	result := rdjsonRange{
+		Start: rdjsonPosition{Line: rng.Start.Line, Column: rng.Start.Column},
+	}
+	if rng.End.Line > 0 {
+		result.End = &rdjsonPosition{Line: rng.End.Line, Column: rng.End.Column}
+	}
+	return result
+}
+
+// rdjsonSeverity maps a severity to a reviewdog severity.
+func rdjsonSeverity(s diagnostic.Severity) string {
+	switch s {
+	case diagnostic.SeverityError:
+		return "ERROR"
+	case diagnostic.SeverityWarning:
+		return "WARNING"
+	default:
+		return "INFO"
+	}
+}
@@ -0,0 +1,152 @@
+// Package reporter provides output formats for lint diagnostics.
+package reporter
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"text/template"
+
+	"github.com/adarshjos/grule-lint/internal/diagnostic"
+	"github.com/adarshjos/grule-lint/internal/rules"
+)
+
+// Reporter renders a set of diagnostics in a particular output format.
+type Reporter interface {
+	// Format returns the name of the output format (e.g., "json").
+	Format() string
+
+	// Report writes the diagnostics.
+	Report(diagnostics *diagnostic.DiagnosticSet) error
+}
+
+// Options holds everything a reporter may need to render its output.
+// Reporters ignore the fields they do not use.
+type Options struct {
+	// Writer receives the rendered output.
+	Writer io.Writer
+
+	// Colorized enables ANSI colors for terminal formats.
+	Colorized bool
+
+	// Sources looks up file contents for formats that embed source code.
+	Sources SourceLookup
+
+	// Registry describes the available lint rules.
+	Registry *rules.Registry
+
+	// ToolVersion is the grule-lint version recorded in the output.
+	ToolVersion string
+
+	// Template is the parsed template for the template format.
+	Template *template.Template
+}
+
+// Factory creates a Reporter from options.
+type Factory func(opts Options) (Reporter, error)
+
+// Registry holds the available output formats.
+type Registry struct {
+	factories map[string]Factory
+	formats   []string
+}
+
+// NewRegistry creates a new empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		factories: make(map[string]Factory),
+		formats:   make([]string, 0),
+	}
+}
+
+// Register registers a factory for an output format, replacing any
+// existing factory with the same name.
+func (r *Registry) Register(format string, factory Factory) {
+	if _, exists := r.factories[format]; !exists {
+		r.formats = append(r.formats, format)
+	}
+	r.factories[format] = factory
+}
+
+// Formats returns the registered format names in registration order.
+func (r *Registry) Formats() []string {
+	formats := make([]string, len(r.formats))
+	copy(formats, r.formats)
+	return formats
+}
+
+// Has returns true if a format is registered.
+func (r *Registry) Has(format string) bool {
+	_, ok := r.factories[format]
+	return ok
+}
+
+// New creates a reporter for the given format.
+func (r *Registry) New(format string, opts Options) (Reporter, error) {
+	factory, ok := r.factories[format]
+	if !ok {
+		return nil, fmt.Errorf("unknown output format %q", format)
+	}
+	if opts.Writer == nil {
+		return nil, errors.New("reporter requires a writer")
+	}
+	return factory(opts)
+}
+
+// DefaultRegistry creates a registry with all built-in formats registered.
+func DefaultRegistry() *Registry {
+	registry := NewRegistry()
+
+	registry.Register("text", func(opts Options) (Reporter, error) {
+		return NewTextReporter(opts.Writer, opts.Colorized), nil
+	})
+	registry.Register("pretty", func(opts Options) (Reporter, error) {
+		return NewPrettyReporter(opts.Writer, opts.Colorized, opts.Sources), nil
+	})
+	registry.Register("json", func(opts Options) (Reporter, error) {
+		return NewJSONReporter(opts.Writer), nil
+	})
+	registry.Register("sarif", func(opts Options) (Reporter, error) {
+		return NewSARIFReporter(opts.Writer, opts.Registry, opts.ToolVersion), nil
+	})
+	registry.Register("checkstyle", func(opts Options) (Reporter, error) {
+		return NewCheckstyleReporter(opts.Writer), nil
+	})
+	registry.Register("junit", func(opts Options) (Reporter, error) {
+		return NewJUnitReporter(opts.Writer), nil
+	})
+	registry.Register("github", func(opts Options) (Reporter, error) {
+		return NewGitHubReporter(opts.Writer), nil
+	})
+	registry.Register("gitlab", func(opts Options) (Reporter, error) {
+		return NewGitLabReporter(opts.Writer), nil
+	})
+	registry.Register("rdjson", func(opts Options) (Reporter, error) {
+		return NewRDJSONReporter(opts.Writer), nil
+	})
+	registry.Register("html", func(opts Options) (Reporter, error) {
+		return NewHTMLReporter(opts.Writer, opts.Sources), nil
+	})
+	registry.Register("template", func(opts Options) (Reporter, error) {
+		if opts.Template == nil {
+			return nil, errors.New("template format requires a template")
+		}
+		return NewTemplateReporter(opts.Writer, opts.Template), nil
+	})
+
+	return registry
+}
+
+var (
+	_ Reporter = (*TextReporter)(nil)
+	_ Reporter = (*PrettyReporter)(nil)
+	_ Reporter = (*JSONReporter)(nil)
+	_ Reporter = (*SARIFReporter)(nil)
+	_ Reporter = (*CheckstyleReporter)(nil)
+	_ Reporter = (*JUnitReporter)(nil)
+	_ Reporter = (*GitHubReporter)(nil)
+	_ Reporter = (*GitLabReporter)(nil)
+	_ Reporter = (*RDJSONReporter)(nil)
+	_ Reporter = (*HTMLReporter)(nil)
+	_ Reporter = (*TemplateReporter)(nil)
+)
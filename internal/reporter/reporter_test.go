@@ -0,0 +1,70 @@
+package reporter
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/adarshjos/grule-lint/internal/diagnostic"
+)
+
+func TestDefaultRegistry(t *testing.T) {
+	registry := DefaultRegistry()
+
+	for _, format := range registry.Formats() {
+		if format == "template" {
+			continue
+		}
+		rep, err := registry.New(format, Options{Writer: &bytes.Buffer{}})
+		if err != nil {
+			t.Errorf("%s: New failed: %v", format, err)
+			continue
+		}
+		if rep.Format() != format {
+			t.Errorf("%s: reporter reports format %q", format, rep.Format())
+		}
+		if err := rep.Report(xmlTestDiagnostics()); err != nil {
+			t.Errorf("%s: Report failed: %v", format, err)
+		}
+	}
+
+	if _, err := registry.New("template", Options{Writer: &bytes.Buffer{}}); err == nil {
+		t.Error("expected error for template format without a template")
+	}
+	if _, err := registry.New("yaml", Options{Writer: &bytes.Buffer{}}); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
+
+type countReporter struct {
+	buf *bytes.Buffer
+}
+
+func (r *countReporter) Format() string { return "count" }
+
+func (r *countReporter) Report(ds *diagnostic.DiagnosticSet) error {
+	r.buf.WriteString("count")
+	return nil
+}
+
+func TestRegistryRegister(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register("count", func(opts Options) (Reporter, error) {
+		return &countReporter{buf: opts.Writer.(*bytes.Buffer)}, nil
+	})
+	registry.Register("count", func(opts Options) (Reporter, error) {
+		return &countReporter{buf: opts.Writer.(*bytes.Buffer)}, nil
+	})
+
+	if formats := registry.Formats(); len(formats) != 1 || formats[0] != "count" {
+		t.Errorf("expected [count], got %v", formats)
+	}
+
+	var buf bytes.Buffer
+	rep, err := registry.New("count", Options{Writer: &buf})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	if err := rep.Report(diagnostic.NewDiagnosticSet()); err != nil || buf.String() != "count" {
+		t.Errorf("unexpected report output %q (err=%v)", buf.String(), err)
+	}
+}
@@ -0,0 +1,246 @@
+package reporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+
+	"github.com/adarshjos/grule-lint/internal/diagnostic"
+	"github.com/adarshjos/grule-lint/internal/rules"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+	toolName     = "grule-lint"
+	toolInfoURI  = "https://github.com/adarshjos/grule-lint"
+)
+
+// SARIFReporter formats diagnostics as a SARIF 2.1.0 log suitable for
+// code-scanning upload.
+type SARIFReporter struct {
+	writer      io.Writer
+	registry    *rules.Registry
+	toolVersion string
+}
+
+// NewSARIFReporter creates a new SARIFReporter. The registry is used to
+// describe the rules in the tool driver section.
+func NewSARIFReporter(w io.Writer, registry *rules.Registry, toolVersion string) *SARIFReporter {
+	return &SARIFReporter{
+		writer:      w,
+		registry:    registry,
+		toolVersion: toolVersion,
+	}
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Version        string      `json:"version,omitempty"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	Name                 string             `json:"name"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex *int            `json:"ruleIndex,omitempty"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+	Fixes     []sarifFix      `json:"fixes,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+type sarifFix struct {
+	Description     sarifMessage          `json:"description"`
+	ArtifactChanges []sarifArtifactChange `json:"artifactChanges"`
+}
+
+type sarifArtifactChange struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Replacements     []sarifReplacement    `json:"replacements"`
+}
+
+type sarifReplacement struct {
+	DeletedRegion   sarifRegion  `json:"deletedRegion"`
+	InsertedContent sarifMessage `json:"insertedContent"`
+}
+
+// Format returns the output format name.
+func (r *SARIFReporter) Format() string {
+	return "sarif"
+}
+
+// Report outputs diagnostics as a SARIF log.
+func (r *SARIFReporter) Report(diagnostics *diagnostic.DiagnosticSet) error {
+	driver := sarifDriver{
+		Name:           toolName,
+		InformationURI: toolInfoURI,
+		Version:        r.toolVersion,
+		Rules:          make([]sarifRule, 0),
+	}
+
+	ruleIndex := make(map[string]int)
+	for _, rule := range r.sortedRules() {
+		ruleIndex[rule.ID()] = len(driver.Rules)
+		driver.Rules = append(driver.Rules, sarifRule{
+			ID:                   rule.ID(),
+			Name:                 rule.Name(),
+			ShortDescription:     sarifMessage{Text: rule.Description()},
+			DefaultConfiguration: sarifConfiguration{Level: sarifLevel(rule.DefaultSeverity())},
+		})
+	}
+
+	run := sarifRun{
+		Tool:    sarifTool{Driver: driver},
+		Results: make([]sarifResult, 0, diagnostics.Count()),
+	}
+
+	for _, d := range diagnostics.Sorted() {
+		uri := filepath.ToSlash(d.File)
+		result := sarifResult{
+			RuleID:  d.RuleID,
+			Level:   sarifLevel(d.Severity),
+			Message: sarifMessage{Text: d.Message},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: uri},
+					Region:           toSARIFRegion(d.Range),
+				},
+			}},
+		}
+		if idx, ok := ruleIndex[d.RuleID]; ok {
+			result.RuleIndex = &idx
+		}
+
+		for _, fix := range d.Fixes {
+			change := sarifArtifactChange{
+				ArtifactLocation: sarifArtifactLocation{URI: uri},
+				Replacements:     make([]sarifReplacement, 0, len(fix.Edits)),
+			}
+			for _, edit := range fix.Edits {
+				change.Replacements = append(change.Replacements, sarifReplacement{
+					DeletedRegion:   toSARIFRegion(edit.Range),
+					InsertedContent: sarifMessage{Text: edit.NewText},
+				})
+			}
+			result.Fixes = append(result.Fixes, sarifFix{
+				Description:     sarifMessage{Text: fix.Description},
+				ArtifactChanges: []sarifArtifactChange{change},
+			})
+		}
+
+		run.Results = append(run.Results, result)
+	}
+
+	doc := sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{run},
+	}
+
+	encoder := json.NewEncoder(r.writer)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return fmt.Errorf("writing SARIF report: %w", err)
+	}
+
+	return nil
+}
+
+// sortedRules returns the registered rules ordered by ID.
+func (r *SARIFReporter) sortedRules() []rules.Rule {
+	if r.registry == nil {
+		return nil
+	}
+
+	all := r.registry.AllRules()
+	sorted := make([]rules.Rule, 0, len(all))
+	for _, rule := range all {
+		sorted = append(sorted, rule)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].ID() < sorted[j].ID()
+	})
+	return sorted
+}
+
+// sarifLevel maps a severity to a SARIF result level.
+func sarifLevel(s diagnostic.Severity) string {
+	switch s {
+	case diagnostic.SeverityError:
+		return "error"
+	case diagnostic.SeverityWarning:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// toSARIFRegion converts a range to a SARIF region. SARIF columns are
+// 1-based and end columns are exclusive, matching diagnostic.Range.
+func toSARIFRegion(rng diagnostic.Range) sarifRegion {
+	region := sarifRegion{
+		StartLine:   rng.Start.Line,
+		StartColumn: rng.Start.Column,
+	}
+	if rng.End.Line > 0 {
+		region.EndLine = rng.End.Line
+		region.EndColumn = rng.End.Column
+	}
+	if region.StartLine < 1 {
+		region.StartLine = 1
+	}
+	return region
+}
@@ -0,0 +1,85 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/adarshjos/grule-lint/internal/diagnostic"
+	"github.com/adarshjos/grule-lint/internal/rules"
+)
+
+func TestSARIFReporter(t *testing.T) {
+	ds := diagnostic.NewDiagnosticSet()
+	ds.Add(diagnostic.Diagnostic{
+		File: "rules/order.grl",
+		Range: diagnostic.Range{
+			Start: diagnostic.Position{Line: 7, Column: 5},
+			End:   diagnostic.Position{Line: 7, Column: 12},
+		},
+		RuleID:   "GRL004",
+		RuleName: "missing-retract",
+		Severity: diagnostic.SeverityWarning,
+		Message:  "Rule 'Foo' does not call Retract()",
+		Fixes: []diagnostic.Fix{{
+			Description: "Add Retract call",
+			Edits: []diagnostic.Edit{{
+				Range:   diagnostic.Range{Start: diagnostic.Position{Line: 8, Column: 1}, End: diagnostic.Position{Line: 8, Column: 1}},
+				NewText: `Retract("Foo");`,
+			}},
+		}},
+	})
+	ds.Add(diagnostic.Diagnostic{
+		File:     "rules/order.grl",
+		Range:    diagnostic.Range{Start: diagnostic.Position{Line: 1, Column: 1}},
+		RuleID:   "GRL003",
+		Severity: diagnostic.SeverityInfo,
+		Message:  "no salience",
+	})
+
+	var buf bytes.Buffer
+	if err := NewSARIFReporter(&buf, rules.DefaultRegistry(), "1.2.3").Report(ds); err != nil {
+		t.Fatalf("Report failed: %v", err)
+	}
+
+	var doc sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid SARIF output: %v", err)
+	}
+
+	if doc.Version != "2.1.0" || len(doc.Runs) != 1 {
+		t.Fatalf("unexpected SARIF envelope: version=%s runs=%d", doc.Version, len(doc.Runs))
+	}
+
+	run := doc.Runs[0]
+	if run.Tool.Driver.Version != "1.2.3" {
+		t.Errorf("expected tool version 1.2.3, got %s", run.Tool.Driver.Version)
+	}
+	if len(run.Tool.Driver.Rules) != len(rules.DefaultRegistry().AllRules()) {
+		t.Errorf("expected one driver rule per registered rule, got %d", len(run.Tool.Driver.Rules))
+	}
+	if run.Tool.Driver.Rules[0].ID != "GRL001" {
+		t.Errorf("expected driver rules sorted by ID, got %s first", run.Tool.Driver.Rules[0].ID)
+	}
+
+	if len(run.Results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(run.Results))
+	}
+
+	info := run.Results[0]
+	if info.RuleID != "GRL003" || info.Level != "note" {
+		t.Errorf("expected GRL003 note first, got %s %s", info.RuleID, info.Level)
+	}
+
+	warn := run.Results[1]
+	if warn.RuleIndex == nil || run.Tool.Driver.Rules[*warn.RuleIndex].ID != "GRL004" {
+		t.Errorf("ruleIndex does not point at GRL004: %v", warn.RuleIndex)
+	}
+	region := warn.Locations[0].PhysicalLocation.Region
+	if region.StartLine != 7 || region.StartColumn != 5 || region.EndLine != 7 || region.EndColumn != 12 {
+		t.Errorf("unexpected region: %+v", region)
+	}
+	if len(warn.Fixes) != 1 || warn.Fixes[0].ArtifactChanges[0].Replacements[0].InsertedContent.Text != `Retract("Foo");` {
+		t.Errorf("unexpected fixes: %+v", warn.Fixes)
+	}
+}
@@ -0,0 +1,120 @@
+package reporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/adarshjos/grule-lint/internal/diagnostic"
+)
+
+// TemplateReporter formats diagnostics with a user-supplied Go text/template.
+type TemplateReporter struct {
+	writer   io.Writer
+	template *template.Template
+}
+
+// TemplateData is the value passed to report templates.
+type TemplateData struct {
+	// Diagnostics contains all diagnostics sorted by file, line, then column.
+	Diagnostics []diagnostic.Diagnostic
+
+	// Files groups the sorted diagnostics per file, ordered by file name.
+	Files []TemplateFile
+
+	// Summary holds the diagnostic counts by severity.
+	Summary TemplateSummary
+}
+
+// TemplateFile holds the diagnostics reported for a single file.
+type TemplateFile struct {
+	Name        string
+	Diagnostics []diagnostic.Diagnostic
+}
+
+// TemplateSummary holds diagnostic counts by severity.
+type TemplateSummary struct {
+	Total    int
+	Errors   int
+	Warnings int
+	Infos    int
+	Hints    int
+}
+
+// templateFuncs are the helper functions available to report templates.
+var templateFuncs = template.FuncMap{
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"json": func(v any) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+}
+
+// NewTemplateReporter creates a new TemplateReporter for a parsed template.
+func NewTemplateReporter(w io.Writer, tmpl *template.Template) *TemplateReporter {
+	return &TemplateReporter{
+		writer:   w,
+		template: tmpl,
+	}
+}
+
+// ParseTemplateFile reads and parses a report template from disk. The
+// template has access to the upper, lower and json helper functions.
+func ParseTemplateFile(path string) (*template.Template, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading template %s: %w", path, err)
+	}
+
+	tmpl, err := template.New(filepath.Base(path)).Funcs(templateFuncs).Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("parsing template %s: %w", path, err)
+	}
+	return tmpl, nil
+}
+
+// Format returns the output format name.
+func (r *TemplateReporter) Format() string {
+	return "template"
+}
+
+// Report executes the template with the diagnostics.
+func (r *TemplateReporter) Report(diagnostics *diagnostic.DiagnosticSet) error {
+	if err := r.template.Execute(r.writer, NewTemplateData(diagnostics)); err != nil {
+		return fmt.Errorf("executing template: %w", err)
+	}
+	return nil
+}
+
+// NewTemplateData builds the template input for a set of diagnostics.
+func NewTemplateData(diagnostics *diagnostic.DiagnosticSet) TemplateData {
+	sorted := diagnostics.Sorted()
+	counts := diagnostics.CountBySeverity()
+
+	data := TemplateData{
+		Diagnostics: sorted,
+		Files:       make([]TemplateFile, 0),
+		Summary: TemplateSummary{
+			Total:    diagnostics.Count(),
+			Errors:   counts[diagnostic.SeverityError],
+			Warnings: counts[diagnostic.SeverityWarning],
+			Infos:    counts[diagnostic.SeverityInfo],
+			Hints:    counts[diagnostic.SeverityHint],
+		},
+	}
+
+	for _, d := range sorted {
+		if len(data.Files) == 0 || data.Files[len(data.Files)-1].Name != d.File {
+			data.Files = append(data.Files, TemplateFile{Name: d.File})
+		}
+		file := &data.Files[len(data.Files)-1]
+		file.Diagnostics = append(file.Diagnostics, d)
+	}
+
+	return data
+}
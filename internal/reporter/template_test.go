@@ -0,0 +1,60 @@
+package reporter
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/adarshjos/grule-lint/internal/diagnostic"
+)
+
+func TestTemplateReporter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.tmpl")
+	content := `{{.Summary.Total}} issues ({{.Summary.Errors}} errors)
+{{range .Files}}{{.Name}}: {{len .Diagnostics}}
+{{range .Diagnostics}}- {{.RuleID}} {{upper .Severity.String}} {{json .Message}}
+{{end}}{{end}}`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl, err := ParseTemplateFile(path)
+	if err != nil {
+		t.Fatalf("ParseTemplateFile failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := NewTemplateReporter(&buf, tmpl).Report(xmlTestDiagnostics()); err != nil {
+		t.Fatalf("Report failed: %v", err)
+	}
+
+	expected := `3 issues (1 errors)
+a.grl: 2
+- GRL003 INFO "Rule 'A' does not specify salience (defaults to 0)"
+- GRL005 ERROR "Duplicate rule name 'A' (first defined at line 1)"
+b.grl: 1
+- GRL002 WARNING "Rule 'B' is missing a description"
+`
+	if buf.String() != expected {
+		t.Errorf("got:\n%s\nwant:\n%s", buf.String(), expected)
+	}
+}
+
+func TestParseTemplateFileInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bad.tmpl")
+	if err := os.WriteFile(path, []byte("{{.Summary"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ParseTemplateFile(path); err == nil {
+		t.Error("expected parse error for malformed template")
+	}
+}
+
+func TestNewTemplateDataEmpty(t *testing.T) {
+	data := NewTemplateData(diagnostic.NewDiagnosticSet())
+	if data.Summary.Total != 0 || len(data.Files) != 0 {
+		t.Errorf("expected empty data, got %+v", data)
+	}
+}
@@ -32,6 +32,11 @@ func NewTextReporter(w io.Writer, colorized bool) *TextReporter {
 	}
 }
 
+// Format returns the output format name.
+func (r *TextReporter) Format() string {
+	return "text"
+}
+
 // Report outputs diagnostics in text format.
 func (r *TextReporter) Report(diagnostics *diagnostic.DiagnosticSet) error {
 	sorted := diagnostics.Sorted()
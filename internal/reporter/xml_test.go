@@ -0,0 +1,142 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+
+	"github.com/adarshjos/grule-lint/internal/diagnostic"
+)
+
+func xmlTestDiagnostics() *diagnostic.DiagnosticSet {
+	ds := diagnostic.NewDiagnosticSet()
+	ds.Add(diagnostic.Diagnostic{
+		File:     "b.grl",
+		Range:    diagnostic.Range{Start: diagnostic.Position{Line: 2, Column: 1}},
+		RuleID:   "GRL002",
+		RuleName: "missing-description",
+		Severity: diagnostic.SeverityWarning,
+		Message:  "Rule 'B' is missing a description",
+	})
+	ds.Add(diagnostic.Diagnostic{
+		File:     "a.grl",
+		Range:    diagnostic.Range{Start: diagnostic.Position{Line: 5, Column: 3}},
+		RuleID:   "GRL005",
+		RuleName: "duplicate-rule",
+		Severity: diagnostic.SeverityError,
+		Message:  "Duplicate rule name 'A' (first defined at line 1)",
+	})
+	ds.Add(diagnostic.Diagnostic{
+		File:     "a.grl",
+		Range:    diagnostic.Range{Start: diagnostic.Position{Line: 1, Column: 1}},
+		RuleID:   "GRL003",
+		RuleName: "missing-salience",
+		Severity: diagnostic.SeverityInfo,
+		Message:  "Rule 'A' does not specify salience (defaults to 0)",
+	})
+	return ds
+}
+
+func TestCheckstyleReporter(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewCheckstyleReporter(&buf).Report(xmlTestDiagnostics()); err != nil {
+		t.Fatalf("Report failed: %v", err)
+	}
+
+	var report checkstyleReport
+	if err := xml.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("invalid XML output: %v\n%s", err, buf.String())
+	}
+
+	if len(report.Files) != 2 {
+		t.Fatalf("expected 2 files, got %d", len(report.Files))
+	}
+	if report.Files[0].Name != "a.grl" || len(report.Files[0].Errors) != 2 {
+		t.Errorf("expected a.grl with 2 errors first, got %+v", report.Files[0])
+	}
+
+	first := report.Files[0].Errors[0]
+	if first.Line != 1 || first.Severity != "info" || first.Source != "GRL003" {
+		t.Errorf("unexpected first error: %+v", first)
+	}
+	if report.Files[0].Errors[1].Severity != "error" {
+		t.Errorf("expected error severity, got %s", report.Files[0].Errors[1].Severity)
+	}
+}
+
+func TestJUnitReporter(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewJUnitReporter(&buf).Report(xmlTestDiagnostics()); err != nil {
+		t.Fatalf("Report failed: %v", err)
+	}
+
+	var report junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("invalid XML output: %v\n%s", err, buf.String())
+	}
+
+	if report.Tests != 3 || report.Failures != 3 {
+		t.Errorf("expected 3 failed tests, got tests=%d failures=%d", report.Tests, report.Failures)
+	}
+	if len(report.Suites) != 2 || report.Suites[0].Name != "a.grl" || report.Suites[0].Failures != 2 {
+		t.Fatalf("unexpected suites: %+v", report.Suites)
+	}
+
+	tc := report.Suites[0].TestCases[1]
+	if tc.Failure.Type != "error" || tc.Failure.Message != "Duplicate rule name 'A' (first defined at line 1)" {
+		t.Errorf("unexpected failure: %+v", tc.Failure)
+	}
+}
+
+func TestJUnitReporterCleanFiles(t *testing.T) {
+	ds := xmlTestDiagnostics()
+	ds.AddFiles("a.grl", "clean.grl")
+
+	var buf bytes.Buffer
+	if err := NewJUnitReporter(&buf).Report(ds); err != nil {
+		t.Fatalf("Report failed: %v", err)
+	}
+
+	var report junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("invalid XML output: %v", err)
+	}
+	if report.Tests != 4 || report.Failures != 3 || len(report.Suites) != 3 {
+		t.Fatalf("expected 3 suites with 4 tests and 3 failures, got %+v", report)
+	}
+
+	clean := report.Suites[2]
+	if clean.Name != "clean.grl" || clean.Tests != 1 || clean.Failures != 0 || clean.TestCases[0].Failure != nil {
+		t.Errorf("expected a passing suite for clean.grl, got %+v", clean)
+	}
+
+	// A run without any findings still produces a suite per file
+	cleanRun := diagnostic.NewDiagnosticSet()
+	cleanRun.AddFiles("clean.grl")
+	buf.Reset()
+	if err := NewJUnitReporter(&buf).Report(cleanRun); err != nil {
+		t.Fatalf("Report failed: %v", err)
+	}
+	report = junitTestSuites{}
+	if err := xml.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("invalid XML output: %v", err)
+	}
+	if len(report.Suites) != 1 || report.Tests != 1 || report.Failures != 0 {
+		t.Errorf("expected one passing suite for a clean run, got %+v", report)
+	}
+}
+
+func TestJUnitReporterEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewJUnitReporter(&buf).Report(diagnostic.NewDiagnosticSet()); err != nil {
+		t.Fatalf("Report failed: %v", err)
+	}
+
+	var report junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("invalid XML output: %v", err)
+	}
+	if report.Tests != 0 || len(report.Suites) != 0 {
+		t.Errorf("expected no suites, got %+v", report)
+	}
+}
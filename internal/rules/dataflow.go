@@ -0,0 +1,236 @@
+package rules
+
+import (
+	"sort"
+
+	"github.com/adarshjos/grule-lint/internal/parser"
+)
+
+// dataflow describes the facts a GRL rule reads in its when clause and
+// writes in its then clause.
+type dataflow struct {
+	reads  [][]parser.PathSegment
+	writes [][]parser.PathSegment
+
+	// writesUnknown is set when the then clause calls a method whose
+	// receiver is not a variable, so the values it changes are unknown.
+	writesUnknown bool
+
+	// stops is set when the rule retracts itself or completes the cycle,
+	// which ends its firing. Retract calls whose argument is not a string
+	// literal may name the rule itself, so they count as well.
+	stops bool
+}
+
+// ruleDataflow collects the reads and writes of a rule. Calling a method
+// in the then clause counts as writing its receiver, since the method may
+// change it.
+func ruleDataflow(rule parser.RuleInfo) dataflow {
+	var flow dataflow
+	for _, v := range rule.ConditionReads {
+		flow.reads = append(flow.reads, v.Path)
+	}
+	for _, v := range rule.VariableAssignments {
+		flow.writes = append(flow.writes, v.Path)
+	}
+	for _, call := range rule.FunctionCalls {
+		switch {
+		case call.Receiver == "" && call.Name == "Complete":
+			flow.stops = true
+		case call.Receiver == "" && call.Name == "Retract":
+			name, ok := call.StringArgument(0)
+			flow.stops = flow.stops || !ok || name == rule.Name
+		case call.Receiver == "":
+			// Built-in functions such as Log and Now change no facts
+		case call.ReceiverPath == nil:
+			flow.writesUnknown = true
+		default:
+			flow.writes = append(flow.writes, call.ReceiverPath)
+		}
+	}
+	return flow
+}
+
+// writesAnyOf reports whether a write may change a value read by other,
+// and returns the first such write.
+func (f dataflow) writesAnyOf(other dataflow) ([]parser.PathSegment, bool) {
+	if f.writesUnknown && len(other.reads) > 0 {
+		return nil, true
+	}
+	for _, w := range f.writes {
+		for _, r := range other.reads {
+			if pathsOverlap(w, r) {
+				return w, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// pathsOverlap reports whether two variable paths may refer to the same
+// value, i.e. one is a prefix of the other. Index selectors match any
+// index.
+func pathsOverlap(a, b []parser.PathSegment) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return false
+	}
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i].Index || b[i].Index {
+			if a[i].Index != b[i].Index {
+				return false
+			}
+			continue
+		}
+		if a[i].Name != b[i].Name {
+			return false
+		}
+	}
+	return true
+}
+
+// dependencyGraph links the rules of a knowledge base: there is an edge
+// from rule A to rule B if A's then clause writes a value B's when clause
+// reads, so that firing A may make B match.
+type dependencyGraph struct {
+	nodes []graphNode
+
+	// edges holds the outgoing edges of each node, in source order.
+	edges [][]graphEdge
+}
+
+type graphNode struct {
+	file string
+	rule parser.RuleInfo
+	flow dataflow
+}
+
+type graphEdge struct {
+	to int
+
+	// write is a path written by the source rule and read by the target.
+	write []parser.PathSegment
+}
+
+// newDependencyGraph builds the graph of the rules of the given files.
+// Method calls with unknown receivers are not treated as writes, so that
+// they do not link every rule to every other.
+func newDependencyGraph(results []*parser.ParseResult) *dependencyGraph {
+	g := &dependencyGraph{}
+	for _, result := range results {
+		for _, rule := range result.Rules {
+			g.nodes = append(g.nodes, graphNode{file: result.File, rule: rule, flow: ruleDataflow(rule)})
+		}
+	}
+
+	g.edges = make([][]graphEdge, len(g.nodes))
+	for i, from := range g.nodes {
+		known := from.flow
+		known.writesUnknown = false
+		for j, to := range g.nodes {
+			if write, ok := known.writesAnyOf(to.flow); ok {
+				g.edges[i] = append(g.edges[i], graphEdge{to: j, write: write})
+			}
+		}
+	}
+	return g
+}
+
+// components returns the strongly connected components of the graph with
+// more than one node, each sorted in source order, using Tarjan's
+// algorithm. Nodes for which skip returns true are left out.
+func (g *dependencyGraph) components(skip func(node int) bool) [][]int {
+	index := make([]int, len(g.nodes))
+	low := make([]int, len(g.nodes))
+	onStack := make([]bool, len(g.nodes))
+	for i := range index {
+		index[i] = -1
+	}
+
+	var stack []int
+	var components [][]int
+	next := 0
+
+	var visit func(v int)
+	visit = func(v int) {
+		index[v], low[v] = next, next
+		next++
+		stack = append(stack, v)
+		onStack[v] = true
+
+		for _, e := range g.edges[v] {
+			switch {
+			case skip(e.to):
+			case index[e.to] < 0:
+				visit(e.to)
+				low[v] = min(low[v], low[e.to])
+			case onStack[e.to]:
+				low[v] = min(low[v], index[e.to])
+			}
+		}
+
+		if low[v] != index[v] {
+			return
+		}
+		var component []int
+		for {
+			w := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[w] = false
+			component = append(component, w)
+			if w == v {
+				break
+			}
+		}
+		if len(component) > 1 {
+			sort.Ints(component)
+			components = append(components, component)
+		}
+	}
+
+	for v := range g.nodes {
+		if index[v] < 0 && !skip(v) {
+			visit(v)
+		}
+	}
+
+	sort.Slice(components, func(i, j int) bool { return components[i][0] < components[j][0] })
+	return components
+}
+
+// shortestCycle returns the edges of a shortest cycle through start that
+// stays within the given nodes, or nil if there is none.
+func (g *dependencyGraph) shortestCycle(start int, within []int) []graphEdge {
+	allowed := make(map[int]bool, len(within))
+	for _, n := range within {
+		allowed[n] = true
+	}
+
+	// Breadth-first search for a path back to start, remembering the edge
+	// each node was reached by
+	via := make(map[int]graphEdge)
+	from := make(map[int]int)
+	queue := []int{start}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		for _, e := range g.edges[v] {
+			if !allowed[e.to] || e.to == v {
+				continue
+			}
+			if e.to == start {
+				cycle := []graphEdge{e}
+				for v != start {
+					cycle = append([]graphEdge{via[v]}, cycle...)
+					v = from[v]
+				}
+				return cycle
+			}
+			if _, seen := via[e.to]; !seen {
+				via[e.to] = e
+				from[e.to] = v
+				queue = append(queue, e.to)
+			}
+		}
+	}
+	return nil
+}
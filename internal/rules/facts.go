@@ -0,0 +1,66 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/adarshjos/grule-lint/internal/parser"
+	"github.com/adarshjos/grule-lint/internal/schema"
+	"github.com/adarshjos/grule-lint/internal/suggest"
+)
+
+// resolvePath follows a variable path through the fact schema. It returns
+// the type reached and, if a member does not exist, the index of that
+// member in the path. Paths starting with an unknown fact resolve to nil
+// and are left to the undefined-variable rule.
+func resolvePath(s *schema.Schema, path []parser.PathSegment) (*schema.Type, int) {
+	if s == nil || len(path) == 0 {
+		return nil, -1
+	}
+	t, ok := s.Fact(path[0].Name)
+	if !ok {
+		return nil, -1
+	}
+
+	for i, seg := range path[1:] {
+		switch {
+		case t.Kind == schema.KindAny:
+			return t, -1
+		case seg.Index:
+			if t.Kind != schema.KindSlice && t.Kind != schema.KindMap {
+				return schema.Any, -1
+			}
+			t = t.Elem
+		default:
+			field, ok := t.Field(seg.Name)
+			if !ok {
+				return t, i + 1
+			}
+			t = field
+		}
+	}
+	return t, -1
+}
+
+// pathText joins the path up to, but not including, segment n.
+func pathText(path []parser.PathSegment, n int) string {
+	text := ""
+	for _, seg := range path[:n] {
+		switch {
+		case seg.Index:
+			text += "[]"
+		case text == "":
+			text = seg.Name
+		default:
+			text += "." + seg.Name
+		}
+	}
+	return text
+}
+
+// didYouMean returns a hint naming the closest candidate, if any.
+func didYouMean(name string, candidates []string) string {
+	if best, ok := suggest.Closest(name, candidates); ok {
+		return fmt.Sprintf("; did you mean '%s'?", best)
+	}
+	return ""
+}
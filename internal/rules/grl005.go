@@ -20,7 +20,7 @@ func (r *DuplicateRuleRule) Name() string {
 }
 
 func (r *DuplicateRuleRule) Description() string {
-	return "Rule names must be unique within a file"
+	return "Rule names must be unique within a file or knowledge base"
 }
 
 func (r *DuplicateRuleRule) DefaultSeverity() diagnostic.Severity {
@@ -53,4 +53,57 @@ func (r *DuplicateRuleRule) CheckKnowledgeBase(file string, result *parser.Parse
 	return diags
 }
 
-var _ SemanticRule = (*DuplicateRuleRule)(nil)
+// CheckProject reports rule names defined in more than one file of a
+// knowledge base. Duplicates within a file are reported per file.
+func (r *DuplicateRuleRule) CheckProject(project *Project) []diagnostic.Diagnostic {
+	var diags []diagnostic.Diagnostic
+	for _, group := range project.KnowledgeBases {
+		diags = append(diags, r.checkGroup(group)...)
+	}
+	return diags
+}
+
+func (r *DuplicateRuleRule) checkGroup(group *KnowledgeBaseGroup) []diagnostic.Diagnostic {
+	var diags []diagnostic.Diagnostic
+
+	type definition struct {
+		file string
+		pos  diagnostic.Position
+	}
+	seen := make(map[string]definition)
+
+	for _, result := range group.Results {
+		reported := make(map[string]bool)
+		for _, ruleInfo := range result.Rules {
+			first, exists := seen[ruleInfo.Name]
+			if !exists {
+				seen[ruleInfo.Name] = definition{file: result.File, pos: ruleInfo.Position}
+				continue
+			}
+			if first.file == result.File || reported[ruleInfo.Name] {
+				continue
+			}
+			reported[ruleInfo.Name] = true
+
+			diags = append(diags, diagnostic.Diagnostic{
+				File: result.File,
+				Range: diagnostic.Range{
+					Start: ruleInfo.Position,
+					End:   ruleInfo.Position,
+				},
+				RuleID:   r.ID(),
+				RuleName: r.Name(),
+				Severity: r.DefaultSeverity(),
+				Message: fmt.Sprintf("Duplicate rule name '%s' in knowledge base '%s' (first defined in %s:%d)",
+					ruleInfo.Name, group.Name, first.file, first.pos.Line),
+			})
+		}
+	}
+
+	return diags
+}
+
+var (
+	_ SemanticRule = (*DuplicateRuleRule)(nil)
+	_ ProjectRule  = (*DuplicateRuleRule)(nil)
+)
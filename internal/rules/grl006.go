@@ -15,10 +15,12 @@ type HighComplexityRule struct {
 	MaxConditions int
 }
 
+// NewHighComplexityRule creates a HighComplexityRule with the default
+// options.
 func NewHighComplexityRule() *HighComplexityRule {
-	return &HighComplexityRule{
-		MaxConditions: DefaultMaxConditions,
-	}
+	r := &HighComplexityRule{}
+	_ = ConfigureRule(r, nil)
+	return r
 }
 
 func (r *HighComplexityRule) ID() string {
@@ -40,13 +42,8 @@ func (r *HighComplexityRule) DefaultSeverity() diagnostic.Severity {
 func (r *HighComplexityRule) CheckKnowledgeBase(file string, result *parser.ParseResult, kb *ast.KnowledgeBase) []diagnostic.Diagnostic {
 	var diags []diagnostic.Diagnostic
 
-	maxConditions := r.MaxConditions
-	if maxConditions == 0 {
-		maxConditions = DefaultMaxConditions
-	}
-
 	for _, ruleInfo := range result.Rules {
-		if ruleInfo.ConditionCount > maxConditions {
+		if ruleInfo.ConditionCount > r.MaxConditions {
 			diags = append(diags, diagnostic.Diagnostic{
 				File: file,
 				Range: diagnostic.Range{
@@ -57,7 +54,7 @@ func (r *HighComplexityRule) CheckKnowledgeBase(file string, result *parser.Pars
 				RuleName: r.Name(),
 				Severity: r.DefaultSeverity(),
 				Message: fmt.Sprintf("Rule '%s' has %d conditions (max %d) - consider splitting into smaller rules",
-					ruleInfo.Name, ruleInfo.ConditionCount, maxConditions),
+					ruleInfo.Name, ruleInfo.ConditionCount, r.MaxConditions),
 			})
 		}
 	}
@@ -65,4 +62,23 @@ func (r *HighComplexityRule) CheckKnowledgeBase(file string, result *parser.Pars
 	return diags
 }
 
-var _ SemanticRule = (*HighComplexityRule)(nil)
+func (r *HighComplexityRule) Options() []Option {
+	return []Option{{
+		Name:        "max-conditions",
+		Description: "Maximum number of conditions allowed in a when clause",
+		Kind:        OptionInt,
+		Default:     DefaultMaxConditions,
+		Min:         1,
+	}}
+}
+
+func (r *HighComplexityRule) Configure(options map[string]any) {
+	if n, ok := options["max-conditions"].(int); ok {
+		r.MaxConditions = n
+	}
+}
+
+var (
+	_ SemanticRule     = (*HighComplexityRule)(nil)
+	_ ConfigurableRule = (*HighComplexityRule)(nil)
+)
@@ -22,17 +22,17 @@ type NamingConventionRule struct {
 	Convention NamingConvention
 }
 
+// NewNamingConventionRule creates a NamingConventionRule with the default
+// options.
 func NewNamingConventionRule() *NamingConventionRule {
-	return &NamingConventionRule{
-		Convention: ConventionPascalCase,
-	}
+	r := &NamingConventionRule{}
+	_ = ConfigureRule(r, nil)
+	return r
 }
 
 // NewNamingConventionRuleWithConfig creates a NamingConventionRule with the specified convention.
 func NewNamingConventionRuleWithConfig(convention string) *NamingConventionRule {
-	r := &NamingConventionRule{
-		Convention: ConventionPascalCase,
-	}
+	r := NewNamingConventionRule()
 	r.SetConvention(convention)
 	return r
 }
@@ -106,3 +106,29 @@ func (r *NamingConventionRule) isValidName(name string) bool {
 		return true
 	}
 }
+
+func (r *NamingConventionRule) Options() []Option {
+	return []Option{{
+		Name:        "convention",
+		Description: "Naming convention rule names must follow",
+		Kind:        OptionString,
+		Default:     string(ConventionPascalCase),
+		Values: []string{
+			string(ConventionPascalCase),
+			string(ConventionCamelCase),
+			string(ConventionSnakeCase),
+			string(ConventionKebabCase),
+		},
+	}}
+}
+
+func (r *NamingConventionRule) Configure(options map[string]any) {
+	if convention, ok := options["convention"].(string); ok {
+		r.SetConvention(convention)
+	}
+}
+
+var (
+	_ SemanticRule     = (*NamingConventionRule)(nil)
+	_ ConfigurableRule = (*NamingConventionRule)(nil)
+)
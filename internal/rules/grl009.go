@@ -2,6 +2,7 @@ package rules
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/adarshjos/grule-lint/internal/diagnostic"
@@ -11,6 +12,11 @@ import (
 
 type UndefinedVariableRule struct {
 	KnownDataContextNames []string
+
+	// ExactNames matches names case-sensitively, as the Grule DataContext
+	// does. It is set when the names come from the config rather than the
+	// built-in guesses.
+	ExactNames bool
 }
 
 func NewUndefinedVariableRule() *UndefinedVariableRule {
@@ -44,7 +50,7 @@ func (r *UndefinedVariableRule) CheckKnowledgeBase(file string, result *parser.P
 
 	knownNames := make(map[string]bool)
 	for _, name := range r.KnownDataContextNames {
-		knownNames[strings.ToLower(name)] = true
+		knownNames[r.normalize(name)] = true
 	}
 
 	for _, rule := range result.Rules {
@@ -58,24 +64,67 @@ func (r *UndefinedVariableRule) CheckKnowledgeBase(file string, result *parser.P
 			baseName := getBaseVarName(v.Name)
 			baseNameLower := strings.ToLower(baseName)
 
-			if seen[baseNameLower] || knownNames[baseNameLower] || localVars[baseNameLower] || builtIns[baseName] {
+			if seen[baseNameLower] || knownNames[r.normalize(baseName)] || localVars[baseNameLower] || builtIns[baseName] {
 				continue
 			}
 
 			seen[baseNameLower] = true
+			message := fmt.Sprintf("Variable '%s' may not be defined in rule '%s'", baseName, rule.Name)
+			if r.ExactNames {
+				message = fmt.Sprintf("Variable '%s' is not in the DataContext in rule '%s'", baseName, rule.Name)
+			}
 			diags = append(diags, diagnostic.Diagnostic{
 				File:     file,
 				Range:    diagnostic.Range{Start: v.Position, End: v.Position},
 				RuleID:   r.ID(),
 				RuleName: r.Name(),
 				Severity: r.DefaultSeverity(),
-				Message:  fmt.Sprintf("Variable '%s' may not be defined in rule '%s'", baseName, rule.Name),
+				Message:  message,
 			})
 		}
 	}
 	return diags
 }
 
+func (r *UndefinedVariableRule) normalize(name string) string {
+	if r.ExactNames {
+		return name
+	}
+	return strings.ToLower(name)
+}
+
+func (r *UndefinedVariableRule) Options() []Option {
+	return []Option{{
+		Name:        "data-context",
+		Description: "Names added to the DataContext; replaces the built-in list of common fact names",
+		Kind:        OptionStringList,
+	}}
+}
+
+// Configure applies the data-context option. The names are copied, since
+// the option value may be shared by the registries of several configs.
+func (r *UndefinedVariableRule) Configure(options map[string]any) {
+	if names, ok := options["data-context"].([]string); ok {
+		r.KnownDataContextNames = slices.Clone(names)
+		r.ExactNames = true
+	}
+}
+
+// AddFacts declares fact names known from a schema. Unless names were
+// configured, they replace the built-in list of common names.
+func (r *UndefinedVariableRule) AddFacts(names []string) {
+	if !r.ExactNames {
+		r.KnownDataContextNames = nil
+		r.ExactNames = true
+	}
+	r.KnownDataContextNames = append(slices.Clone(r.KnownDataContextNames), names...)
+}
+
+var (
+	_ SemanticRule     = (*UndefinedVariableRule)(nil)
+	_ ConfigurableRule = (*UndefinedVariableRule)(nil)
+)
+
 var builtIns = map[string]bool{
 	"Retract": true, "Log": true, "Now": true, "IsNil": true, "IsZero": true,
 	"Len": true, "MakeTime": true, "Changed": true, "Complete": true,
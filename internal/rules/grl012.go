@@ -89,3 +89,72 @@ func (r *ConflictingRulesRule) CheckKnowledgeBase(file string, result *parser.Pa
 
 	return diags
 }
+
+// CheckProject reports rules with the same when clause as a rule in
+// another file of a knowledge base. Rules sharing a when clause within a
+// file are reported per file.
+func (r *ConflictingRulesRule) CheckProject(project *Project) []diagnostic.Diagnostic {
+	var diags []diagnostic.Diagnostic
+	for _, group := range project.KnowledgeBases {
+		diags = append(diags, r.checkGroup(group)...)
+	}
+	return diags
+}
+
+func (r *ConflictingRulesRule) checkGroup(group *KnowledgeBaseGroup) []diagnostic.Diagnostic {
+	var diags []diagnostic.Diagnostic
+
+	type definition struct {
+		file string
+		rule parser.RuleInfo
+	}
+	first := make(map[string]definition)
+
+	for _, result := range group.Results {
+		reported := make(map[string]bool)
+		for _, rule := range result.Rules {
+			when := rule.WhenExpressionText
+			if when == "" {
+				continue
+			}
+			other, exists := first[when]
+			if !exists {
+				first[when] = definition{file: result.File, rule: rule}
+				continue
+			}
+			// A rule defined again under the same name is a duplicate, which
+			// GRL005 reports
+			if other.file == result.File || reported[when] || other.rule.Name == rule.Name {
+				continue
+			}
+			reported[when] = true
+
+			severity := r.DefaultSeverity()
+			detail := "this may cause conflicts"
+			if rule.Salience != other.rule.Salience {
+				severity = diagnostic.SeverityInfo
+				detail = "salience differs so execution order is defined"
+			}
+
+			diags = append(diags, diagnostic.Diagnostic{
+				File: result.File,
+				Range: diagnostic.Range{
+					Start: rule.Position,
+					End:   rule.Position,
+				},
+				RuleID:   r.ID(),
+				RuleName: r.Name(),
+				Severity: severity,
+				Message: fmt.Sprintf("Rule '%s' has the same when clause as '%s' (in %s:%d) in knowledge base '%s' - %s",
+					rule.Name, other.rule.Name, other.file, other.rule.Position.Line, group.Name, detail),
+			})
+		}
+	}
+
+	return diags
+}
+
+var (
+	_ SemanticRule = (*ConflictingRulesRule)(nil)
+	_ ProjectRule  = (*ConflictingRulesRule)(nil)
+)
@@ -0,0 +1,79 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/adarshjos/grule-lint/internal/diagnostic"
+	"github.com/adarshjos/grule-lint/internal/parser"
+)
+
+type UnusedSuppressionRule struct{}
+
+func (r *UnusedSuppressionRule) ID() string {
+	return "GRL013"
+}
+
+func (r *UnusedSuppressionRule) Name() string {
+	return "unused-suppression"
+}
+
+func (r *UnusedSuppressionRule) Description() string {
+	return "Checks for grule-lint suppression comments that do not silence any issue"
+}
+
+func (r *UnusedSuppressionRule) DefaultSeverity() diagnostic.Severity {
+	return diagnostic.SeverityWarning
+}
+
+func (r *UnusedSuppressionRule) CheckSuppressions(file string, result *parser.ParseResult, unused []UnusedSuppression) []diagnostic.Diagnostic {
+	var diags []diagnostic.Diagnostic
+
+	for _, u := range unused {
+		s := u.Suppression
+		d := diagnostic.Diagnostic{
+			File:     file,
+			Range:    s.Range,
+			RuleID:   r.ID(),
+			RuleName: r.Name(),
+			Severity: r.DefaultSeverity(),
+		}
+
+		switch {
+		case s.Kind == parser.SuppressEnd:
+			d.Message = fmt.Sprintf("Unused %s directive - no matching suppression is active", s.Kind)
+		case len(u.RuleIDs) == 0:
+			d.Message = fmt.Sprintf("Unused %s directive - no issues were suppressed", s.Kind)
+		default:
+			d.Message = fmt.Sprintf("Unused %s directive for %s - no such issues were suppressed", s.Kind, strings.Join(u.RuleIDs, ", "))
+		}
+
+		// Only offer to delete the comment if none of it is in use
+		if len(u.RuleIDs) == len(s.RuleIDs) {
+			d.Fixes = []diagnostic.Fix{removeCommentFix(s)}
+		}
+
+		diags = append(diags, d)
+	}
+
+	return diags
+}
+
+// removeCommentFix deletes a directive comment, including its line if the
+// comment stands alone.
+func removeCommentFix(s parser.Suppression) diagnostic.Fix {
+	rng := s.Range
+	if s.OwnLine {
+		rng = diagnostic.Range{
+			Start: diagnostic.Position{Line: s.Range.Start.Line, Column: 1},
+			End:   diagnostic.Position{Line: s.Range.End.Line + 1, Column: 1},
+		}
+	}
+
+	return diagnostic.Fix{
+		Description: "Remove the unused suppression comment",
+		Edits:       []diagnostic.Edit{{Range: rng, NewText: ""}},
+	}
+}
+
+var _ SuppressionRule = (*UnusedSuppressionRule)(nil)
@@ -0,0 +1,76 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/hyperjumptech/grule-rule-engine/ast"
+
+	"github.com/adarshjos/grule-lint/internal/diagnostic"
+	"github.com/adarshjos/grule-lint/internal/parser"
+	"github.com/adarshjos/grule-lint/internal/schema"
+)
+
+// UnknownFieldRule reports access to fields that the fact schema does not
+// declare. It does nothing without a schema.
+type UnknownFieldRule struct {
+	Schema *schema.Schema
+}
+
+func (r *UnknownFieldRule) ID() string {
+	return "GRL014"
+}
+
+func (r *UnknownFieldRule) Name() string {
+	return "unknown-field"
+}
+
+func (r *UnknownFieldRule) Description() string {
+	return "Fields accessed on facts must exist in the fact schema"
+}
+
+func (r *UnknownFieldRule) DefaultSeverity() diagnostic.Severity {
+	return diagnostic.SeverityError
+}
+
+func (r *UnknownFieldRule) CheckKnowledgeBase(file string, result *parser.ParseResult, kb *ast.KnowledgeBase) []diagnostic.Diagnostic {
+	if r.Schema == nil {
+		return nil
+	}
+
+	var diags []diagnostic.Diagnostic
+	for _, rule := range result.Rules {
+		// Nested variables share their prefixes, so each unknown member
+		// would otherwise be reported once per enclosing variable
+		reported := make(map[diagnostic.Position]bool)
+
+		for _, v := range rule.VariableUsages {
+			t, bad := resolvePath(r.Schema, v.Path)
+			if bad < 0 || reported[v.Path[bad].Position] {
+				continue
+			}
+			seg := v.Path[bad]
+			reported[seg.Position] = true
+
+			message := fmt.Sprintf("Unknown field '%s' on %s (%s) in rule '%s'",
+				seg.Name, pathText(v.Path, bad), t, rule.Name)
+			if t.Kind == schema.KindStruct {
+				message += didYouMean(seg.Name, t.FieldNames())
+			}
+
+			diags = append(diags, diagnostic.Diagnostic{
+				File: file,
+				Range: diagnostic.Range{
+					Start: seg.Position,
+					End:   diagnostic.Position{Line: seg.Position.Line, Column: seg.Position.Column + len(seg.Name)},
+				},
+				RuleID:   r.ID(),
+				RuleName: r.Name(),
+				Severity: r.DefaultSeverity(),
+				Message:  message,
+			})
+		}
+	}
+	return diags
+}
+
+var _ SemanticRule = (*UnknownFieldRule)(nil)
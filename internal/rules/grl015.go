@@ -0,0 +1,69 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/hyperjumptech/grule-rule-engine/ast"
+
+	"github.com/adarshjos/grule-lint/internal/diagnostic"
+	"github.com/adarshjos/grule-lint/internal/parser"
+	"github.com/adarshjos/grule-lint/internal/schema"
+)
+
+// UnknownMethodRule reports calls to methods that the fact schema does not
+// declare. Only methods of struct types are checked, since Grule provides
+// built-in methods for strings, slices, maps and times.
+type UnknownMethodRule struct {
+	Schema *schema.Schema
+}
+
+func (r *UnknownMethodRule) ID() string {
+	return "GRL015"
+}
+
+func (r *UnknownMethodRule) Name() string {
+	return "unknown-method"
+}
+
+func (r *UnknownMethodRule) Description() string {
+	return "Methods called on facts must exist in the fact schema"
+}
+
+func (r *UnknownMethodRule) DefaultSeverity() diagnostic.Severity {
+	return diagnostic.SeverityError
+}
+
+func (r *UnknownMethodRule) CheckKnowledgeBase(file string, result *parser.ParseResult, kb *ast.KnowledgeBase) []diagnostic.Diagnostic {
+	if r.Schema == nil {
+		return nil
+	}
+
+	var diags []diagnostic.Diagnostic
+	for _, rule := range result.Rules {
+		for _, call := range rule.MethodCalls {
+			t, bad := resolvePath(r.Schema, call.ReceiverPath)
+			if t == nil || bad >= 0 || t.Kind != schema.KindStruct {
+				continue
+			}
+			if _, ok := t.Method(call.Name); ok {
+				continue
+			}
+
+			diags = append(diags, diagnostic.Diagnostic{
+				File: file,
+				Range: diagnostic.Range{
+					Start: call.Position,
+					End:   diagnostic.Position{Line: call.Position.Line, Column: call.Position.Column + len(call.Name)},
+				},
+				RuleID:   r.ID(),
+				RuleName: r.Name(),
+				Severity: r.DefaultSeverity(),
+				Message: fmt.Sprintf("Unknown method '%s' on %s (%s) in rule '%s'%s",
+					call.Name, call.Receiver, t, rule.Name, didYouMean(call.Name, t.MethodNames())),
+			})
+		}
+	}
+	return diags
+}
+
+var _ SemanticRule = (*UnknownMethodRule)(nil)
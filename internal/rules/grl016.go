@@ -0,0 +1,74 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/hyperjumptech/grule-rule-engine/ast"
+
+	"github.com/adarshjos/grule-lint/internal/diagnostic"
+	"github.com/adarshjos/grule-lint/internal/parser"
+	"github.com/adarshjos/grule-lint/internal/schema"
+)
+
+// ArgumentCountRule reports fact method calls whose number of arguments
+// does not match the signature in the fact schema.
+type ArgumentCountRule struct {
+	Schema *schema.Schema
+}
+
+func (r *ArgumentCountRule) ID() string {
+	return "GRL016"
+}
+
+func (r *ArgumentCountRule) Name() string {
+	return "argument-count"
+}
+
+func (r *ArgumentCountRule) Description() string {
+	return "Fact method calls must pass the number of arguments in the fact schema"
+}
+
+func (r *ArgumentCountRule) DefaultSeverity() diagnostic.Severity {
+	return diagnostic.SeverityError
+}
+
+func (r *ArgumentCountRule) CheckKnowledgeBase(file string, result *parser.ParseResult, kb *ast.KnowledgeBase) []diagnostic.Diagnostic {
+	if r.Schema == nil {
+		return nil
+	}
+
+	var diags []diagnostic.Diagnostic
+	for _, rule := range result.Rules {
+		for _, call := range rule.MethodCalls {
+			t, bad := resolvePath(r.Schema, call.ReceiverPath)
+			if t == nil || bad >= 0 || t.Kind != schema.KindStruct {
+				continue
+			}
+			method, ok := t.Method(call.Name)
+			if !ok || method.AcceptsArgs(len(call.Arguments)) {
+				continue
+			}
+
+			expected := fmt.Sprintf("%d", len(method.Params))
+			if method.Variadic {
+				expected = fmt.Sprintf("at least %d", len(method.Params)-1)
+			}
+
+			diags = append(diags, diagnostic.Diagnostic{
+				File: file,
+				Range: diagnostic.Range{
+					Start: call.Position,
+					End:   diagnostic.Position{Line: call.Position.Line, Column: call.Position.Column + len(call.Name)},
+				},
+				RuleID:   r.ID(),
+				RuleName: r.Name(),
+				Severity: r.DefaultSeverity(),
+				Message: fmt.Sprintf("Method '%s' of %s expects %s argument(s) %s, got %d in rule '%s'",
+					call.Name, t, expected, method.Signature(), len(call.Arguments), rule.Name),
+			})
+		}
+	}
+	return diags
+}
+
+var _ SemanticRule = (*ArgumentCountRule)(nil)
@@ -0,0 +1,147 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/adarshjos/grule-lint/internal/diagnostic"
+	"github.com/adarshjos/grule-lint/internal/parser"
+	"github.com/adarshjos/grule-lint/internal/suggest"
+)
+
+// RetractMismatchRule checks the string literals passed to Retract. A rule
+// that retracts another rule instead of itself, e.g. after a copy-paste or
+// a typo, is never retracted and may fire again in the next cycle.
+// Retracting other rules is allowed when the rule also retracts itself.
+type RetractMismatchRule struct{}
+
+func (r *RetractMismatchRule) ID() string {
+	return "GRL018"
+}
+
+func (r *RetractMismatchRule) Name() string {
+	return "retract-mismatch"
+}
+
+func (r *RetractMismatchRule) Description() string {
+	return "Retract() should name the enclosing rule or an existing rule"
+}
+
+func (r *RetractMismatchRule) DefaultSeverity() diagnostic.Severity {
+	return diagnostic.SeverityWarning
+}
+
+// CheckProject checks the Retract calls of every file. Rule names are
+// resolved against all files loaded into the same knowledge bases.
+func (r *RetractMismatchRule) CheckProject(project *Project) []diagnostic.Diagnostic {
+	// known holds the rule names loaded with each file. Files loaded with
+	// a file that failed to parse have an incomplete list and are skipped
+	// when checking that retracted rules exist.
+	known := make(map[*parser.ParseResult]map[string]bool)
+	incomplete := make(map[*parser.ParseResult]bool)
+	for _, set := range project.RuleSets() {
+		names := make(map[string]bool)
+		complete := true
+		for _, result := range set {
+			complete = complete && len(result.Errors) == 0
+			for _, rule := range result.Rules {
+				names[rule.Name] = true
+			}
+		}
+		for _, result := range set {
+			if known[result] == nil {
+				known[result] = make(map[string]bool)
+			}
+			for name := range names {
+				known[result][name] = true
+			}
+			incomplete[result] = incomplete[result] || !complete
+		}
+	}
+
+	var diags []diagnostic.Diagnostic
+	for _, result := range project.Results {
+		if len(result.Errors) > 0 {
+			continue
+		}
+		for _, rule := range result.Rules {
+			diags = append(diags, r.checkRule(result.File, rule, known[result], !incomplete[result])...)
+		}
+	}
+	return diags
+}
+
+func (r *RetractMismatchRule) checkRule(file string, rule parser.RuleInfo, known map[string]bool, checkExists bool) []diagnostic.Diagnostic {
+	type retract struct {
+		name string
+		rng  diagnostic.Range
+	}
+	var retracts []retract
+	retractsSelf := false
+	for _, call := range rule.FunctionCalls {
+		if call.Name != "Retract" || call.Receiver != "" {
+			continue
+		}
+		name, ok := call.StringArgument(0)
+		if !ok {
+			continue
+		}
+		if name == rule.Name {
+			retractsSelf = true
+			continue
+		}
+		retracts = append(retracts, retract{name: name, rng: call.ArgumentRanges[0]})
+	}
+
+	var diags []diagnostic.Diagnostic
+	for _, rt := range retracts {
+		exists := known[rt.name] || !checkExists
+		if exists && retractsSelf {
+			continue
+		}
+
+		d := diagnostic.Diagnostic{
+			File:     file,
+			Range:    rt.rng,
+			RuleID:   r.ID(),
+			RuleName: r.Name(),
+			Severity: r.DefaultSeverity(),
+		}
+
+		// Offer the enclosing rule unless it is already retracted, in
+		// which case the closest existing rule name is the likely intent
+		target := rule.Name
+		if exists {
+			d.Message = fmt.Sprintf("Rule '%s' retracts '%s' instead of itself - it may fire again in the next cycle", rule.Name, rt.name)
+		} else {
+			d.Message = fmt.Sprintf("Rule '%s' retracts '%s', which is not a rule in its knowledge base", rule.Name, rt.name)
+			if retractsSelf {
+				target = ""
+				if closest, ok := suggest.Closest(rt.name, ruleNames(known)); ok {
+					target = closest
+				}
+			}
+		}
+
+		if target != "" {
+			d.Fixes = []diagnostic.Fix{{
+				Description: fmt.Sprintf("Retract '%s' instead", target),
+				Edits:       []diagnostic.Edit{{Range: rt.rng, NewText: strconv.Quote(target)}},
+			}}
+		}
+		diags = append(diags, d)
+	}
+	return diags
+}
+
+func ruleNames(known map[string]bool) []string {
+	names := make([]string, 0, len(known))
+	for name := range known {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+var _ ProjectRule = (*RetractMismatchRule)(nil)
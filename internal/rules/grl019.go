@@ -0,0 +1,69 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/hyperjumptech/grule-rule-engine/ast"
+
+	"github.com/adarshjos/grule-lint/internal/diagnostic"
+	"github.com/adarshjos/grule-lint/internal/parser"
+)
+
+// InfiniteLoopRule reports rules that keep firing until Grule's MaxCycle
+// limit: the rule neither retracts nor calls Complete(), and its then
+// clause changes nothing its when clause reads, so the condition stays
+// true. Unlike GRL004, rules whose writes may falsify their own condition
+// are not reported.
+type InfiniteLoopRule struct{}
+
+func (r *InfiniteLoopRule) ID() string {
+	return "GRL019"
+}
+
+func (r *InfiniteLoopRule) Name() string {
+	return "infinite-loop"
+}
+
+func (r *InfiniteLoopRule) Description() string {
+	return "Rule cannot make its own condition false and never retracts"
+}
+
+func (r *InfiniteLoopRule) DefaultSeverity() diagnostic.Severity {
+	return diagnostic.SeverityWarning
+}
+
+func (r *InfiniteLoopRule) CheckKnowledgeBase(file string, result *parser.ParseResult, kb *ast.KnowledgeBase) []diagnostic.Diagnostic {
+	var diags []diagnostic.Diagnostic
+
+	for _, rule := range result.Rules {
+		flow := ruleDataflow(rule)
+		if flow.stops {
+			continue
+		}
+		if _, falsifies := flow.writesAnyOf(flow); falsifies {
+			continue
+		}
+
+		detail := "its then clause changes nothing its when clause reads"
+		if len(flow.reads) == 0 {
+			detail = "its when clause reads no facts"
+		}
+
+		diags = append(diags, diagnostic.Diagnostic{
+			File: file,
+			Range: diagnostic.Range{
+				Start: rule.ThenPosition,
+				End:   rule.ThenPosition,
+			},
+			RuleID:   r.ID(),
+			RuleName: r.Name(),
+			Severity: r.DefaultSeverity(),
+			Message: fmt.Sprintf("Rule '%s' fires again every cycle: it does not call Retract() or Complete() and %s",
+				rule.Name, detail),
+		})
+	}
+
+	return diags
+}
+
+var _ SemanticRule = (*InfiniteLoopRule)(nil)
@@ -0,0 +1,100 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/adarshjos/grule-lint/internal/diagnostic"
+	"github.com/adarshjos/grule-lint/internal/parser"
+)
+
+// RuleCycleRule reports rules that keep triggering each other: each rule
+// of the cycle writes a value the next one reads, and none of them
+// retracts or calls Complete(), so Grule only stops at MaxCycle.
+type RuleCycleRule struct{}
+
+func (r *RuleCycleRule) ID() string {
+	return "GRL020"
+}
+
+func (r *RuleCycleRule) Name() string {
+	return "rule-cycle"
+}
+
+func (r *RuleCycleRule) Description() string {
+	return "Rules that do not retract trigger each other in a cycle"
+}
+
+func (r *RuleCycleRule) DefaultSeverity() diagnostic.Severity {
+	return diagnostic.SeverityWarning
+}
+
+// CheckProject builds the dependency graph of each set of files loaded
+// together and reports one cycle per group of rules that trigger each
+// other. Sets with files that failed to parse are skipped.
+func (r *RuleCycleRule) CheckProject(project *Project) []diagnostic.Diagnostic {
+	var diags []diagnostic.Diagnostic
+	reported := make(map[string]bool)
+
+	for _, set := range project.RuleSets() {
+		if !allParsed(set) {
+			continue
+		}
+
+		g := newDependencyGraph(set)
+		stops := func(node int) bool { return g.nodes[node].flow.stops }
+		for _, component := range g.components(stops) {
+			start := component[0]
+			cycle := g.shortestCycle(start, component)
+			if cycle == nil {
+				continue
+			}
+
+			d := diagnostic.Diagnostic{
+				File: g.nodes[start].file,
+				Range: diagnostic.Range{
+					Start: g.nodes[start].rule.Position,
+					End:   g.nodes[start].rule.Position,
+				},
+				RuleID:   r.ID(),
+				RuleName: r.Name(),
+				Severity: r.DefaultSeverity(),
+				Message: fmt.Sprintf("Rules trigger each other without retracting: %s - Grule only stops at MaxCycle",
+					cyclePath(g, start, cycle)),
+			}
+
+			// A file in several knowledge bases may show the same cycle
+			key := fmt.Sprintf("%s:%d:%s", d.File, d.Range.Start.Line, d.Message)
+			if !reported[key] {
+				reported[key] = true
+				diags = append(diags, d)
+			}
+		}
+	}
+
+	return diags
+}
+
+// cyclePath describes a cycle, e.g. "A (writes Order.Status) -> B (writes
+// Order.Total) -> A".
+func cyclePath(g *dependencyGraph, start int, cycle []graphEdge) string {
+	var b strings.Builder
+	from := start
+	for _, e := range cycle {
+		fmt.Fprintf(&b, "%s (writes %s) -> ", g.nodes[from].rule.Name, pathText(e.write, len(e.write)))
+		from = e.to
+	}
+	b.WriteString(g.nodes[start].rule.Name)
+	return b.String()
+}
+
+func allParsed(results []*parser.ParseResult) bool {
+	for _, result := range results {
+		if len(result.Errors) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+var _ ProjectRule = (*RuleCycleRule)(nil)
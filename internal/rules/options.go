@@ -0,0 +1,209 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// OptionKind is the type of a rule option value.
+type OptionKind int
+
+const (
+	// OptionInt is an integer option.
+	OptionInt OptionKind = iota
+	// OptionString is a string option.
+	OptionString
+	// OptionBool is a boolean option.
+	OptionBool
+	// OptionStringList is a list of strings.
+	OptionStringList
+)
+
+func (k OptionKind) String() string {
+	switch k {
+	case OptionInt:
+		return "integer"
+	case OptionString:
+		return "string"
+	case OptionBool:
+		return "boolean"
+	case OptionStringList:
+		return "list of strings"
+	default:
+		return "unknown"
+	}
+}
+
+// Option describes a setting accepted by a configurable rule.
+type Option struct {
+	// Name is the option key in the config (e.g., "max-conditions").
+	Name string
+
+	// Description explains what the option controls. It is shown with
+	// errors for invalid values.
+	Description string
+
+	// Kind is the type of the option value.
+	Kind OptionKind
+
+	// Default is the value ConfigureRule applies when the option is not
+	// set. Nil leaves the rule unchanged.
+	Default any
+
+	// Min is the smallest allowed value for integer options.
+	Min int
+
+	// Values lists the allowed values for string options. Empty means
+	// any string is allowed.
+	Values []string
+}
+
+// Check validates a raw config value for the option and returns it
+// converted to the option's Go type: int, string, bool or []string.
+// Errors include the option's description.
+func (o Option) Check(value any) (any, error) {
+	checked, err := o.check(value)
+	if err != nil && o.Description != "" {
+		return nil, fmt.Errorf("%w (%s)", err, o.Description)
+	}
+	return checked, err
+}
+
+func (o Option) check(value any) (any, error) {
+	switch o.Kind {
+	case OptionInt:
+		n, ok := toInt(value)
+		if !ok {
+			return nil, fmt.Errorf("option %s must be an integer", o.Name)
+		}
+		if n < o.Min {
+			return nil, fmt.Errorf("option %s must be at least %d, got %d", o.Name, o.Min, n)
+		}
+		return n, nil
+
+	case OptionString:
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("option %s must be a string", o.Name)
+		}
+		if len(o.Values) > 0 && !containsString(o.Values, s) {
+			return nil, fmt.Errorf("option %s must be one of %s, got %q", o.Name, strings.Join(o.Values, ", "), s)
+		}
+		return s, nil
+
+	case OptionBool:
+		b, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("option %s must be true or false", o.Name)
+		}
+		return b, nil
+
+	case OptionStringList:
+		switch v := value.(type) {
+		case []string:
+			return v, nil
+		case []any:
+			list := make([]string, 0, len(v))
+			for _, item := range v {
+				s, ok := item.(string)
+				if !ok {
+					return nil, fmt.Errorf("option %s must be a list of strings", o.Name)
+				}
+				list = append(list, s)
+			}
+			return list, nil
+		default:
+			return nil, fmt.Errorf("option %s must be a list of strings", o.Name)
+		}
+	}
+
+	return nil, fmt.Errorf("option %s has unsupported kind %s", o.Name, o.Kind)
+}
+
+// ConfigurableRule is a rule that accepts options from the config.
+type ConfigurableRule interface {
+	Rule
+
+	// Options describes the options accepted by the rule.
+	Options() []Option
+
+	// Configure applies options. Values have already been checked and
+	// converted by Option.Check, and only declared options are passed.
+	Configure(options map[string]any)
+}
+
+// FindOption returns the option with the given name, or false if the rule
+// does not declare it.
+func FindOption(rule ConfigurableRule, name string) (Option, bool) {
+	for _, o := range rule.Options() {
+		if o.Name == name {
+			return o, true
+		}
+	}
+	return Option{}, false
+}
+
+// ConfigureRule checks raw option values against the rule's declared
+// options and applies the valid ones, along with the defaults of options
+// that are not set or invalid. It returns an error for each unknown option and
+// invalid value.
+func ConfigureRule(rule ConfigurableRule, options map[string]any) error {
+	names := make([]string, 0, len(options))
+	for name := range options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []error
+	checked := make(map[string]any, len(options))
+	for _, name := range names {
+		opt, ok := FindOption(rule, name)
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s does not have an option %q", rule.ID(), name))
+			continue
+		}
+		value, err := opt.Check(options[name])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", rule.ID(), err))
+			continue
+		}
+		checked[name] = value
+	}
+	for _, opt := range rule.Options() {
+		if _, valid := checked[opt.Name]; !valid && opt.Default != nil {
+			checked[opt.Name] = opt.Default
+		}
+	}
+
+	rule.Configure(checked)
+	return errors.Join(errs...)
+}
+
+// toInt converts YAML and JSON numbers to int.
+func toInt(value any) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case int64:
+		return int(v), true
+	case uint64:
+		return int(v), true
+	case float64:
+		if v == math.Trunc(v) {
+			return int(v), true
+		}
+	}
+	return 0, false
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
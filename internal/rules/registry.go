@@ -1,23 +1,33 @@
 package rules
 
 import (
+	"errors"
+	"fmt"
+	"maps"
+	"sort"
+
 	"github.com/adarshjos/grule-lint/internal/diagnostic"
 	"github.com/adarshjos/grule-lint/internal/parser"
+	"github.com/adarshjos/grule-lint/internal/schema"
 )
 
 // Registry holds all registered lint rules.
 type Registry struct {
-	syntaxRules   []SyntaxRule
-	semanticRules []SemanticRule
-	allRules      map[string]Rule
+	syntaxRules      []SyntaxRule
+	semanticRules    []SemanticRule
+	suppressionRules []SuppressionRule
+	projectRules     []ProjectRule
+	allRules         map[string]Rule
 }
 
 // NewRegistry creates a new empty Registry.
 func NewRegistry() *Registry {
 	return &Registry{
-		syntaxRules:   make([]SyntaxRule, 0),
-		semanticRules: make([]SemanticRule, 0),
-		allRules:      make(map[string]Rule),
+		syntaxRules:      make([]SyntaxRule, 0),
+		semanticRules:    make([]SemanticRule, 0),
+		suppressionRules: make([]SuppressionRule, 0),
+		projectRules:     make([]ProjectRule, 0),
+		allRules:         make(map[string]Rule),
 	}
 }
 
@@ -33,6 +43,19 @@ func (r *Registry) RegisterSemantic(rule SemanticRule) {
 	r.allRules[rule.ID()] = rule
 }
 
+// RegisterSuppression registers a suppression rule.
+func (r *Registry) RegisterSuppression(rule SuppressionRule) {
+	r.suppressionRules = append(r.suppressionRules, rule)
+	r.allRules[rule.ID()] = rule
+}
+
+// RegisterProject registers a project rule. A rule may also be registered
+// as a semantic rule to check single files.
+func (r *Registry) RegisterProject(rule ProjectRule) {
+	r.projectRules = append(r.projectRules, rule)
+	r.allRules[rule.ID()] = rule
+}
+
 // SyntaxRules returns all registered syntax rules.
 func (r *Registry) SyntaxRules() []SyntaxRule {
 	return r.syntaxRules
@@ -43,6 +66,16 @@ func (r *Registry) SemanticRules() []SemanticRule {
 	return r.semanticRules
 }
 
+// SuppressionRules returns all registered suppression rules.
+func (r *Registry) SuppressionRules() []SuppressionRule {
+	return r.suppressionRules
+}
+
+// ProjectRules returns all registered project rules.
+func (r *Registry) ProjectRules() []ProjectRule {
+	return r.projectRules
+}
+
 // GetRule returns a rule by ID, or nil if not found.
 func (r *Registry) GetRule(id string) Rule {
 	return r.allRules[id]
@@ -77,6 +110,33 @@ func (r *Registry) RunSemanticRules(result *parser.ParseResult) []diagnostic.Dia
 	return diags
 }
 
+// RunSuppressionRules runs all suppression rules against the unused
+// suppressions of a parse result.
+func (r *Registry) RunSuppressionRules(result *parser.ParseResult, unused []UnusedSuppression) []diagnostic.Diagnostic {
+	if len(unused) == 0 {
+		return nil
+	}
+
+	var diags []diagnostic.Diagnostic
+	for _, rule := range r.suppressionRules {
+		diags = append(diags, rule.CheckSuppressions(result.File, result, unused)...)
+	}
+	return diags
+}
+
+// RunProjectRules runs all project rules against the files of a lint run.
+func (r *Registry) RunProjectRules(project *Project) []diagnostic.Diagnostic {
+	if len(project.Results) == 0 {
+		return nil
+	}
+
+	var diags []diagnostic.Diagnostic
+	for _, rule := range r.projectRules {
+		diags = append(diags, rule.CheckProject(project)...)
+	}
+	return diags
+}
+
 // RegistryConfig holds configuration options for creating a registry.
 type RegistryConfig struct {
 	// NamingConvention specifies the naming convention for GRL007.
@@ -85,16 +145,58 @@ type RegistryConfig struct {
 
 	// MaxConditions specifies the max conditions for GRL006.
 	MaxConditions int
+
+	// RuleOptions holds options for configurable rules, keyed by rule ID
+	// and option name. Options are applied after the fields above.
+	RuleOptions map[string]map[string]any
+
+	// Rules holds the configured severity of rules, keyed by rule ID, as
+	// in the config file. The linter drops the diagnostics of rules set to
+	// "off" and reports the others with the configured severity.
+	Rules map[string]string
+
+	// Schema describes the DataContext facts. When set, its fact names are
+	// known to GRL009 and GRL014-GRL016 check fields and methods.
+	Schema *schema.Schema
+}
+
+// ruleOptions returns RuleOptions with MaxConditions and NamingConvention
+// added as the GRL006 and GRL007 options they set, unless RuleOptions sets
+// those options too.
+func (cfg RegistryConfig) ruleOptions() map[string]map[string]any {
+	legacy := map[string]map[string]any{}
+	if cfg.MaxConditions > 0 {
+		legacy["GRL006"] = map[string]any{"max-conditions": cfg.MaxConditions}
+	}
+	if cfg.NamingConvention != "" {
+		legacy["GRL007"] = map[string]any{"convention": cfg.NamingConvention}
+	}
+
+	options := make(map[string]map[string]any, len(cfg.RuleOptions)+len(legacy))
+	for id, values := range legacy {
+		options[id] = values
+	}
+	for id, values := range cfg.RuleOptions {
+		merged := make(map[string]any, len(values)+len(options[id]))
+		maps.Copy(merged, options[id])
+		maps.Copy(merged, values)
+		options[id] = merged
+	}
+	return options
 }
 
 // DefaultRegistry creates a registry with all built-in rules registered.
 func DefaultRegistry() *Registry {
-	return DefaultRegistryWithConfig(RegistryConfig{})
+	// The default configuration has no options that could fail
+	registry, _ := DefaultRegistryWithConfig(RegistryConfig{})
+	return registry
 }
 
 // DefaultRegistryWithConfig creates a registry with all built-in rules
-// registered using the provided configuration.
-func DefaultRegistryWithConfig(cfg RegistryConfig) *Registry {
+// registered using the provided configuration. Invalid rule options are
+// returned as an error; the registry is still usable, with the valid
+// options applied.
+func DefaultRegistryWithConfig(cfg RegistryConfig) (*Registry, error) {
 	registry := NewRegistry()
 
 	// Register syntax rules
@@ -104,28 +206,86 @@ func DefaultRegistryWithConfig(cfg RegistryConfig) *Registry {
 	registry.RegisterSemantic(&MissingDescriptionRule{})
 	registry.RegisterSemantic(&MissingSalienceRule{})
 	registry.RegisterSemantic(&MissingRetractRule{})
-	registry.RegisterSemantic(&DuplicateRuleRule{})
+	duplicateRule := &DuplicateRuleRule{}
+	registry.RegisterSemantic(duplicateRule)
 
 	// High complexity rule with configurable max conditions
-	complexityRule := NewHighComplexityRule()
-	if cfg.MaxConditions > 0 {
-		complexityRule.MaxConditions = cfg.MaxConditions
-	}
-	registry.RegisterSemantic(complexityRule)
+	registry.RegisterSemantic(&HighComplexityRule{})
 
 	// Register advanced semantic rules (Phase 4)
 	// Naming convention rule with configurable convention
-	if cfg.NamingConvention != "" {
-		registry.RegisterSemantic(NewNamingConventionRuleWithConfig(cfg.NamingConvention))
-	} else {
-		registry.RegisterSemantic(NewNamingConventionRule())
-	}
+	registry.RegisterSemantic(&NamingConventionRule{})
 
 	registry.RegisterSemantic(&EmptyWhenRule{})
 	registry.RegisterSemantic(&EmptyThenRule{})
 	registry.RegisterSemantic(&UnusedVariableRule{})
-	registry.RegisterSemantic(NewUndefinedVariableRule())
-	registry.RegisterSemantic(&ConflictingRulesRule{})
+	undefinedRule := NewUndefinedVariableRule()
+	registry.RegisterSemantic(undefinedRule)
+	conflictingRule := &ConflictingRulesRule{}
+	registry.RegisterSemantic(conflictingRule)
 
-	return registry
+	// Fact schema rules, which only report when a schema is configured
+	registry.RegisterSemantic(&UnknownFieldRule{Schema: cfg.Schema})
+	registry.RegisterSemantic(&UnknownMethodRule{Schema: cfg.Schema})
+	registry.RegisterSemantic(&ArgumentCountRule{Schema: cfg.Schema})
+
+	// Data flow rules, which compare what rules read and write
+	registry.RegisterSemantic(&InfiniteLoopRule{})
+
+	// Register project rules, which check all files of a run together
+	registry.RegisterProject(duplicateRule)
+	registry.RegisterProject(conflictingRule)
+	registry.RegisterProject(&RetractMismatchRule{})
+	registry.RegisterProject(&RuleCycleRule{})
+
+	// Register suppression rules
+	registry.RegisterSuppression(&UnusedSuppressionRule{})
+
+	err := registry.ConfigureRules(cfg.ruleOptions())
+
+	if cfg.Schema != nil {
+		undefinedRule.AddFacts(cfg.Schema.FactNames())
+	}
+
+	return registry, err
+}
+
+// ConfigureRules applies options to the configurable rules, keyed by rule
+// ID. Every configurable rule is configured, so options that are not set
+// get their defaults. It returns an error for unknown rules, rules without
+// options, and invalid option values; valid options of other rules are
+// still applied.
+func (r *Registry) ConfigureRules(options map[string]map[string]any) error {
+	ids := make([]string, 0, len(options))
+	for id := range options {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var errs []error
+	for _, id := range ids {
+		rule := r.allRules[id]
+		if rule == nil {
+			errs = append(errs, fmt.Errorf("unknown rule %s", id))
+			continue
+		}
+		if _, ok := rule.(ConfigurableRule); !ok {
+			errs = append(errs, fmt.Errorf("%s does not accept options", id))
+		}
+	}
+
+	ids = ids[:0]
+	for id, rule := range r.allRules {
+		if _, ok := rule.(ConfigurableRule); ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		if err := ConfigureRule(r.allRules[id].(ConfigurableRule), options[id]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
 }
@@ -41,3 +41,76 @@ type SemanticRule interface {
 	// It receives the full ParseResult which includes accurate position info.
 	CheckKnowledgeBase(file string, result *parser.ParseResult, kb *ast.KnowledgeBase) []diagnostic.Diagnostic
 }
+
+// UnusedSuppression describes a suppression directive that did not silence
+// any diagnostic.
+type UnusedSuppression struct {
+	Suppression parser.Suppression
+
+	// RuleIDs lists the unused rule IDs of the directive. It is empty when
+	// the directive applies to all rules.
+	RuleIDs []string
+}
+
+// SuppressionRule is a rule that runs after suppression comments have been
+// applied. These rules analyze the suppressions themselves.
+type SuppressionRule interface {
+	Rule
+
+	// CheckSuppressions analyzes the unused suppressions of a file.
+	CheckSuppressions(file string, result *parser.ParseResult, unused []UnusedSuppression) []diagnostic.Diagnostic
+}
+
+// KnowledgeBaseGroup is a set of files loaded into one Grule knowledge base
+// under the same name and version.
+type KnowledgeBaseGroup struct {
+	Name    string
+	Version string
+
+	// Results holds the parse results of the files, in load order.
+	Results []*parser.ParseResult
+
+	// KnowledgeBase is the knowledge base built from all files.
+	KnowledgeBase *ast.KnowledgeBase
+}
+
+// Project holds the parse results of a lint run, grouped by the knowledge
+// bases they are loaded into.
+type Project struct {
+	// Results holds the parse results of every file, in lint order.
+	Results []*parser.ParseResult
+
+	// KnowledgeBases holds the declared knowledge bases that contain at
+	// least one of the files.
+	KnowledgeBases []*KnowledgeBaseGroup
+}
+
+// RuleSets returns the sets of files Grule loads together: the files of
+// each knowledge base, and every file outside the knowledge bases on its
+// own.
+func (p *Project) RuleSets() [][]*parser.ParseResult {
+	grouped := make(map[*parser.ParseResult]bool)
+	sets := make([][]*parser.ParseResult, 0, len(p.KnowledgeBases))
+	for _, group := range p.KnowledgeBases {
+		sets = append(sets, group.Results)
+		for _, result := range group.Results {
+			grouped[result] = true
+		}
+	}
+	for _, result := range p.Results {
+		if !grouped[result] {
+			sets = append(sets, []*parser.ParseResult{result})
+		}
+	}
+	return sets
+}
+
+// ProjectRule is a rule that runs after the per-file rules on all files of
+// a lint run, finding issues that span several rules or files.
+type ProjectRule interface {
+	Rule
+
+	// CheckProject analyzes the files of a lint run and returns
+	// diagnostics.
+	CheckProject(project *Project) []diagnostic.Diagnostic
+}
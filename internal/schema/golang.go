@@ -0,0 +1,230 @@
+package schema
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// LoadGoPackage builds a schema from Go struct types, type-checking the
+// package in dir from source. Facts maps each DataContext name to the name
+// of a type declared in the package. As with ast.DataContext.Add, the
+// exported fields (including promoted ones) and the exported methods of
+// the pointer type are visible to rules.
+func LoadGoPackage(dir string, facts map[string]string) (*Schema, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("resolving package directory %s: %w", dir, err)
+	}
+
+	pkgInfo, err := build.ImportDir(absDir, 0)
+	if err != nil {
+		return nil, fmt.Errorf("loading Go package %s: %w", dir, err)
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, name := range pkgInfo.GoFiles {
+		f, err := parser.ParseFile(fset, filepath.Join(absDir, name), nil, 0)
+		if err != nil {
+			return nil, fmt.Errorf("parsing Go package %s: %w", dir, err)
+		}
+		files = append(files, f)
+	}
+
+	// Type errors are tolerated: types that cannot be resolved, e.g.
+	// because of a missing dependency, become "any" and are not checked
+	var typeErrors []error
+	conf := types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+		Error:    func(err error) { typeErrors = append(typeErrors, err) },
+	}
+	pkg, _ := conf.Check(pkgInfo.ImportPath, fset, files, nil)
+	if pkg == nil {
+		return nil, fmt.Errorf("type-checking Go package %s failed", dir)
+	}
+
+	names := make([]string, 0, len(facts))
+	for name := range facts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	c := &goConverter{named: make(map[*types.Named]*Type)}
+	s := &Schema{Facts: make(map[string]*Type, len(facts))}
+	for _, name := range names {
+		typeName := facts[name]
+		obj, ok := pkg.Scope().Lookup(typeName).(*types.TypeName)
+		if !ok {
+			err := fmt.Errorf("type %s not found in Go package %s", typeName, dir)
+			if len(typeErrors) > 0 {
+				err = fmt.Errorf("%w (first type error: %v)", err, typeErrors[0])
+			}
+			return nil, err
+		}
+		s.Facts[name] = c.convert(obj.Type())
+	}
+	return s, nil
+}
+
+// goConverter converts Go types to schema types.
+type goConverter struct {
+	// named caches named types, which may refer to themselves.
+	named map[*types.Named]*Type
+}
+
+func (c *goConverter) convert(t types.Type) *Type {
+	switch t := t.(type) {
+	case *types.Basic:
+		switch {
+		case t.Info()&types.IsString != 0:
+			return &Type{Kind: KindString}
+		case t.Info()&types.IsInteger != 0:
+			return &Type{Kind: KindInt}
+		case t.Info()&types.IsFloat != 0:
+			return &Type{Kind: KindFloat}
+		case t.Info()&types.IsBoolean != 0:
+			return &Type{Kind: KindBool}
+		}
+		return Any
+
+	case *types.Pointer:
+		return c.convert(t.Elem())
+
+	case *types.Slice:
+		return &Type{Kind: KindSlice, Elem: c.convert(t.Elem())}
+
+	case *types.Array:
+		return &Type{Kind: KindSlice, Elem: c.convert(t.Elem())}
+
+	case *types.Map:
+		return &Type{Kind: KindMap, Key: c.convert(t.Key()), Elem: c.convert(t.Elem())}
+
+	case *types.Named:
+		return c.convertNamed(t)
+
+	case *types.Alias:
+		return c.convert(types.Unalias(t))
+
+	case *types.Struct:
+		st := &Type{Kind: KindStruct}
+		c.fillStruct(st, t, nil)
+		return st
+	}
+	return Any
+}
+
+func (c *goConverter) convertNamed(t *types.Named) *Type {
+	if cached, ok := c.named[t]; ok {
+		return cached
+	}
+
+	obj := t.Obj()
+	if obj.Pkg() != nil && obj.Pkg().Path() == "time" && obj.Name() == "Time" {
+		return &Type{Kind: KindTime}
+	}
+
+	st, ok := t.Underlying().(*types.Struct)
+	if !ok {
+		// Named basic types, slices and maps behave like their
+		// underlying type in rules
+		converted := c.convert(t.Underlying())
+		c.named[t] = converted
+		return converted
+	}
+
+	result := &Type{Kind: KindStruct, Name: obj.Name()}
+	c.named[t] = result
+	c.fillStruct(result, st, nil)
+	c.fillMethods(result, types.NewPointer(t))
+	return result
+}
+
+// fillStruct adds the exported fields of a struct, including fields
+// promoted from embedded structs. Outer fields shadow promoted ones.
+func (c *goConverter) fillStruct(result *Type, st *types.Struct, seen map[*types.Struct]bool) {
+	if result.Fields == nil {
+		result.Fields = make(map[string]*Type)
+	}
+	if seen == nil {
+		seen = make(map[*types.Struct]bool)
+	}
+	if seen[st] {
+		return
+	}
+	seen[st] = true
+
+	var embedded []*types.Var
+	for i := 0; i < st.NumFields(); i++ {
+		field := st.Field(i)
+		if field.Embedded() {
+			embedded = append(embedded, field)
+		}
+		if !field.Exported() {
+			continue
+		}
+		if _, exists := result.Fields[field.Name()]; !exists {
+			result.Fields[field.Name()] = c.convert(field.Type())
+		}
+	}
+
+	for _, field := range embedded {
+		t := field.Type()
+		if p, ok := t.(*types.Pointer); ok {
+			t = p.Elem()
+		}
+		if inner, ok := t.Underlying().(*types.Struct); ok {
+			c.fillStruct(result, inner, seen)
+		}
+	}
+}
+
+// fillMethods adds the exported methods of a type's method set.
+func (c *goConverter) fillMethods(result *Type, t types.Type) {
+	result.Methods = make(map[string]*Method)
+
+	methods := types.NewMethodSet(t)
+	for i := 0; i < methods.Len(); i++ {
+		fn, ok := methods.At(i).Obj().(*types.Func)
+		if !ok || !fn.Exported() {
+			continue
+		}
+		sig := fn.Type().(*types.Signature)
+
+		m := &Method{Variadic: sig.Variadic()}
+		for j := 0; j < sig.Params().Len(); j++ {
+			param := sig.Params().At(j).Type()
+			// The variadic parameter is a slice of the element type
+			if m.Variadic && j == sig.Params().Len()-1 {
+				if s, ok := param.(*types.Slice); ok {
+					param = s.Elem()
+				}
+			}
+			m.Params = append(m.Params, c.convert(param))
+		}
+		if sig.Results().Len() > 0 {
+			m.Returns = c.convert(sig.Results().At(0).Type())
+		}
+		result.Methods[fn.Name()] = m
+	}
+}
+
+// ParseFactTypes parses fact declarations written as Name=Type.
+func ParseFactTypes(values []string) (map[string]string, error) {
+	facts := make(map[string]string, len(values))
+	for _, v := range values {
+		name, typeName, ok := strings.Cut(v, "=")
+		if !ok || name == "" || typeName == "" {
+			return nil, fmt.Errorf("invalid fact %q: expected Name=Type", v)
+		}
+		facts[name] = typeName
+	}
+	return facts, nil
+}
@@ -0,0 +1,103 @@
+package schema
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadGoPackage(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module example.com/facts\n\ngo 1.25\n",
+		"facts.go": `package facts
+
+import "time"
+
+type Base struct {
+	ID string
+}
+
+type Item struct {
+	Name string
+}
+
+type OrderFact struct {
+	Base
+	Total   float64
+	Items   []*Item
+	Created time.Time
+	Next    *OrderFact
+	secret  int
+}
+
+func (o *OrderFact) AddItem(name string, qty int) bool { return true }
+func (o OrderFact) Sum(values ...float64) float64      { return 0 }
+func (o *OrderFact) reset()                            {}
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s, err := LoadGoPackage(dir, map[string]string{"Order": "OrderFact"})
+	if err != nil {
+		t.Fatalf("LoadGoPackage failed: %v", err)
+	}
+
+	order, ok := s.Fact("Order")
+	if !ok {
+		t.Fatal("expected fact Order")
+	}
+
+	fieldTypes := map[string]string{
+		"ID":      "string",
+		"Total":   "float",
+		"Items":   "[]Item",
+		"Created": "time",
+		"Next":    "OrderFact",
+	}
+	for name, want := range fieldTypes {
+		field, ok := order.Field(name)
+		if !ok {
+			t.Errorf("missing field %s", name)
+			continue
+		}
+		if field.String() != want {
+			t.Errorf("field %s: got %s, want %s", name, field, want)
+		}
+	}
+	if _, ok := order.Field("secret"); ok {
+		t.Error("unexported field should not be visible")
+	}
+
+	if m, ok := order.Method("AddItem"); !ok || m.Signature() != "(string, int)" {
+		t.Errorf("unexpected AddItem: %v", m)
+	}
+	if m, ok := order.Method("Sum"); !ok || m.Signature() != "(...float)" {
+		t.Errorf("unexpected Sum: %v", m)
+	}
+	if _, ok := order.Method("reset"); ok {
+		t.Error("unexported method should not be visible")
+	}
+
+	if _, err := LoadGoPackage(dir, map[string]string{"Order": "Missing"}); err == nil {
+		t.Error("expected an error for an unknown type")
+	}
+}
+
+func TestParseFactTypes(t *testing.T) {
+	facts, err := ParseFactTypes([]string{"Order=OrderFact", "Env=Environment"})
+	if err != nil {
+		t.Fatalf("ParseFactTypes failed: %v", err)
+	}
+	if facts["Order"] != "OrderFact" || facts["Env"] != "Environment" {
+		t.Errorf("unexpected facts: %v", facts)
+	}
+
+	if _, err := ParseFactTypes([]string{"Order"}); err == nil {
+		t.Error("expected an error without a type")
+	}
+}
@@ -0,0 +1,262 @@
+package schema
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Load reads a schema file. YAML and JSON are both accepted:
+//
+//	facts:
+//	  Order: OrderFact
+//	types:
+//	  OrderFact:
+//	    fields:
+//	      Total: float
+//	      Items: "[]Item"
+//	    methods:
+//	      AddItem:
+//	        params: [string, int]
+//	        returns: bool
+//	  Item:
+//	    fields:
+//	      Name: string
+//
+// Types are written as string, int, float, bool, time, any, []T, map[K]V,
+// the name of an entry under types, or an inline mapping of fields and
+// methods. Go names such as int64, float64 and time.Time are accepted too.
+// A final parameter written as ...T is variadic.
+func Load(path string) (*Schema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading schema file %s: %w", path, err)
+	}
+	return Parse(path, data)
+}
+
+// Parse parses schema data. The name is used in error messages.
+func Parse(name string, data []byte) (*Schema, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parsing schema file %s: %w", name, err)
+	}
+
+	p := &schemaParser{file: name, named: make(map[string]*Type)}
+	s := &Schema{Facts: make(map[string]*Type)}
+	if len(doc.Content) == 0 {
+		return s, nil
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, p.errorf(root, "schema must be a mapping with facts and types")
+	}
+
+	// Declare named types first so they can refer to each other
+	var facts, types *yaml.Node
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+		switch key.Value {
+		case "facts":
+			facts = value
+		case "types":
+			types = value
+		default:
+			return nil, p.errorf(key, "unknown key %q (valid: facts, types)", key.Value)
+		}
+	}
+
+	if types != nil {
+		if types.Kind != yaml.MappingNode {
+			return nil, p.errorf(types, "types must be a mapping")
+		}
+		for i := 0; i+1 < len(types.Content); i += 2 {
+			name := types.Content[i].Value
+			if _, builtin := builtinTypes[name]; builtin {
+				return nil, p.errorf(types.Content[i], "type %q shadows a built-in type", name)
+			}
+			p.named[name] = &Type{Kind: KindStruct, Name: name}
+		}
+		for i := 0; i+1 < len(types.Content); i += 2 {
+			t := p.named[types.Content[i].Value]
+			if err := p.parseStruct(types.Content[i+1], t); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if facts != nil {
+		if facts.Kind != yaml.MappingNode {
+			return nil, p.errorf(facts, "facts must be a mapping")
+		}
+		for i := 0; i+1 < len(facts.Content); i += 2 {
+			t, err := p.parseType(facts.Content[i+1])
+			if err != nil {
+				return nil, err
+			}
+			s.Facts[facts.Content[i].Value] = t
+		}
+	}
+
+	return s, nil
+}
+
+// builtinTypes maps type names, including Go spellings, to kinds.
+var builtinTypes = map[string]Kind{
+	"any": KindAny, "interface{}": KindAny,
+	"string": KindString,
+	"int":    KindInt, "int8": KindInt, "int16": KindInt, "int32": KindInt, "int64": KindInt,
+	"uint": KindInt, "uint8": KindInt, "uint16": KindInt, "uint32": KindInt, "uint64": KindInt,
+	"float": KindFloat, "float32": KindFloat, "float64": KindFloat,
+	"bool": KindBool,
+	"time": KindTime, "time.Time": KindTime,
+}
+
+type schemaParser struct {
+	file  string
+	named map[string]*Type
+}
+
+func (p *schemaParser) errorf(node *yaml.Node, format string, args ...any) error {
+	return fmt.Errorf("%s:%d:%d: %s", p.file, node.Line, node.Column, fmt.Sprintf(format, args...))
+}
+
+// parseType parses a type expression or an inline struct.
+func (p *schemaParser) parseType(node *yaml.Node) (*Type, error) {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		t, err := p.parseTypeExpr(strings.TrimSpace(node.Value))
+		if err != nil {
+			return nil, p.errorf(node, "%v", err)
+		}
+		return t, nil
+	case yaml.MappingNode:
+		t := &Type{Kind: KindStruct}
+		return t, p.parseStruct(node, t)
+	default:
+		return nil, p.errorf(node, "type must be a type name or a mapping of fields and methods")
+	}
+}
+
+// parseTypeExpr parses a type written as a string.
+func (p *schemaParser) parseTypeExpr(expr string) (*Type, error) {
+	expr = strings.TrimPrefix(expr, "*")
+
+	if kind, ok := builtinTypes[expr]; ok {
+		return &Type{Kind: kind}, nil
+	}
+	if t, ok := p.named[expr]; ok {
+		return t, nil
+	}
+
+	if elem, ok := strings.CutPrefix(expr, "[]"); ok {
+		t, err := p.parseTypeExpr(elem)
+		if err != nil {
+			return nil, err
+		}
+		return &Type{Kind: KindSlice, Elem: t}, nil
+	}
+
+	if rest, ok := strings.CutPrefix(expr, "map["); ok {
+		end := strings.Index(rest, "]")
+		if end < 0 {
+			return nil, fmt.Errorf("invalid map type %q", expr)
+		}
+		key, err := p.parseTypeExpr(rest[:end])
+		if err != nil {
+			return nil, err
+		}
+		value, err := p.parseTypeExpr(rest[end+1:])
+		if err != nil {
+			return nil, err
+		}
+		return &Type{Kind: KindMap, Key: key, Elem: value}, nil
+	}
+
+	return nil, fmt.Errorf("unknown type %q", expr)
+}
+
+// parseStruct fills a struct type from a mapping of fields and methods.
+func (p *schemaParser) parseStruct(node *yaml.Node, t *Type) error {
+	if node.Kind != yaml.MappingNode {
+		return p.errorf(node, "struct type must be a mapping of fields and methods")
+	}
+
+	t.Fields = make(map[string]*Type)
+	t.Methods = make(map[string]*Method)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if value.Kind != yaml.MappingNode {
+			return p.errorf(value, "%s must be a mapping", key.Value)
+		}
+
+		switch key.Value {
+		case "fields":
+			for j := 0; j+1 < len(value.Content); j += 2 {
+				field, err := p.parseType(value.Content[j+1])
+				if err != nil {
+					return err
+				}
+				t.Fields[value.Content[j].Value] = field
+			}
+		case "methods":
+			for j := 0; j+1 < len(value.Content); j += 2 {
+				method, err := p.parseMethod(value.Content[j+1])
+				if err != nil {
+					return err
+				}
+				t.Methods[value.Content[j].Value] = method
+			}
+		default:
+			return p.errorf(key, "unknown key %q in struct type (valid: fields, methods)", key.Value)
+		}
+	}
+	return nil
+}
+
+// parseMethod parses a method with optional params and returns.
+func (p *schemaParser) parseMethod(node *yaml.Node) (*Method, error) {
+	m := &Method{}
+	if node.Kind == yaml.ScalarNode && node.Value == "" {
+		return m, nil
+	}
+	if node.Kind != yaml.MappingNode {
+		return nil, p.errorf(node, "method must be a mapping with params and returns")
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		switch key.Value {
+		case "params":
+			if value.Kind != yaml.SequenceNode {
+				return nil, p.errorf(value, "params must be a list of types")
+			}
+			for j, param := range value.Content {
+				if rest, ok := strings.CutPrefix(param.Value, "..."); ok && param.Kind == yaml.ScalarNode {
+					if j != len(value.Content)-1 {
+						return nil, p.errorf(param, "only the last parameter can be variadic")
+					}
+					m.Variadic = true
+					param = &yaml.Node{Kind: yaml.ScalarNode, Value: rest, Line: param.Line, Column: param.Column}
+				}
+				t, err := p.parseType(param)
+				if err != nil {
+					return nil, err
+				}
+				m.Params = append(m.Params, t)
+			}
+		case "returns":
+			t, err := p.parseType(value)
+			if err != nil {
+				return nil, err
+			}
+			m.Returns = t
+		default:
+			return nil, p.errorf(key, "unknown key %q in method (valid: params, returns)", key.Value)
+		}
+	}
+	return m, nil
+}
@@ -0,0 +1,178 @@
+// Package schema describes the facts added to the Grule DataContext: their
+// fields, methods and types. Schemas are loaded from YAML or JSON files.
+package schema
+
+import (
+	"sort"
+	"strings"
+)
+
+// Kind is the kind of a fact type.
+type Kind int
+
+const (
+	// KindAny is a value whose type is not known; nothing is checked on it.
+	KindAny Kind = iota
+	KindString
+	KindInt
+	KindFloat
+	KindBool
+	KindTime
+	KindSlice
+	KindMap
+	KindStruct
+)
+
+var kindNames = map[Kind]string{
+	KindAny:    "any",
+	KindString: "string",
+	KindInt:    "int",
+	KindFloat:  "float",
+	KindBool:   "bool",
+	KindTime:   "time",
+	KindSlice:  "slice",
+	KindMap:    "map",
+	KindStruct: "struct",
+}
+
+func (k Kind) String() string {
+	if name, ok := kindNames[k]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// Type describes the type of a fact, field, parameter or return value.
+type Type struct {
+	Kind Kind
+
+	// Name is the name of a named struct type. Empty for inline structs
+	// and other kinds.
+	Name string
+
+	// Key is the key type of a map.
+	Key *Type
+
+	// Elem is the element type of a slice or the value type of a map.
+	Elem *Type
+
+	// Fields and Methods are the members of a struct.
+	Fields  map[string]*Type
+	Methods map[string]*Method
+}
+
+// Method describes a method of a struct type.
+type Method struct {
+	Params []*Type
+
+	// Variadic reports whether the last parameter accepts any number of
+	// arguments.
+	Variadic bool
+
+	// Returns is the result type, or nil if the method returns nothing.
+	Returns *Type
+}
+
+// Any is the type used when a type is unknown.
+var Any = &Type{Kind: KindAny}
+
+// String returns the type in Go-like notation, e.g. "[]Item".
+func (t *Type) String() string {
+	switch t.Kind {
+	case KindSlice:
+		return "[]" + t.Elem.String()
+	case KindMap:
+		return "map[" + t.Key.String() + "]" + t.Elem.String()
+	case KindStruct:
+		if t.Name != "" {
+			return t.Name
+		}
+		return "struct"
+	default:
+		return t.Kind.String()
+	}
+}
+
+// Field returns the field with the given name. Only structs have fields.
+func (t *Type) Field(name string) (*Type, bool) {
+	field, ok := t.Fields[name]
+	return field, ok
+}
+
+// Method returns the method with the given name. Only structs have methods.
+func (t *Type) Method(name string) (*Method, bool) {
+	method, ok := t.Methods[name]
+	return method, ok
+}
+
+// FieldNames returns the sorted field names of a struct.
+func (t *Type) FieldNames() []string {
+	return sortedKeys(t.Fields)
+}
+
+// MethodNames returns the sorted method names of a struct.
+func (t *Type) MethodNames() []string {
+	return sortedKeys(t.Methods)
+}
+
+// AcceptsArgs reports whether the method can be called with n arguments.
+func (m *Method) AcceptsArgs(n int) bool {
+	if m.Variadic {
+		return n >= len(m.Params)-1
+	}
+	return n == len(m.Params)
+}
+
+// Signature returns the parameter list in Go-like notation, e.g.
+// "(string, ...int)".
+func (m *Method) Signature() string {
+	params := make([]string, len(m.Params))
+	for i, p := range m.Params {
+		params[i] = p.String()
+		if m.Variadic && i == len(m.Params)-1 {
+			params[i] = "..." + params[i]
+		}
+	}
+	return "(" + strings.Join(params, ", ") + ")"
+}
+
+// Schema holds the facts of a DataContext, keyed by the name they are
+// added under.
+type Schema struct {
+	Facts map[string]*Type
+}
+
+// Fact returns the type of a fact.
+func (s *Schema) Fact(name string) (*Type, bool) {
+	fact, ok := s.Facts[name]
+	return fact, ok
+}
+
+// FactNames returns the sorted fact names.
+func (s *Schema) FactNames() []string {
+	return sortedKeys(s.Facts)
+}
+
+// Merge adds the facts of other that s does not declare.
+func (s *Schema) Merge(other *Schema) {
+	if other == nil {
+		return
+	}
+	if s.Facts == nil {
+		s.Facts = make(map[string]*Type, len(other.Facts))
+	}
+	for name, t := range other.Facts {
+		if _, exists := s.Facts[name]; !exists {
+			s.Facts[name] = t
+		}
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,120 @@
+package schema
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	s, err := Parse("facts.yaml", []byte(`
+facts:
+  Order: OrderFact
+  Env:
+    fields:
+      Limit: int64
+types:
+  OrderFact:
+    fields:
+      Total: float64
+      Items: "[]Item"
+      Tags: "map[string][]string"
+      Created: time.Time
+    methods:
+      AddItem:
+        params: [string, int]
+        returns: bool
+      Sum:
+        params: ["...float"]
+  Item:
+    fields:
+      Name: string
+`))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	if got := s.FactNames(); strings.Join(got, ",") != "Env,Order" {
+		t.Errorf("FactNames() = %v", got)
+	}
+
+	order, _ := s.Fact("Order")
+	fieldTypes := map[string]string{
+		"Total":   "float",
+		"Items":   "[]Item",
+		"Tags":    "map[string][]string",
+		"Created": "time",
+	}
+	for name, want := range fieldTypes {
+		field, ok := order.Field(name)
+		if !ok {
+			t.Errorf("missing field %s", name)
+			continue
+		}
+		if field.String() != want {
+			t.Errorf("field %s: got %s, want %s", name, field, want)
+		}
+	}
+
+	items, _ := order.Field("Items")
+	if _, ok := items.Elem.Field("Name"); !ok {
+		t.Error("expected Item.Name through the named type")
+	}
+
+	env, _ := s.Fact("Env")
+	if limit, _ := env.Field("Limit"); limit.Kind != KindInt {
+		t.Errorf("expected inline fact field Limit to be int, got %s", limit)
+	}
+
+	addItem, _ := order.Method("AddItem")
+	if !addItem.AcceptsArgs(2) || addItem.AcceptsArgs(1) || addItem.Signature() != "(string, int)" {
+		t.Errorf("unexpected AddItem signature %s", addItem.Signature())
+	}
+	sum, _ := order.Method("Sum")
+	if !sum.Variadic || !sum.AcceptsArgs(0) || !sum.AcceptsArgs(3) || sum.Signature() != "(...float)" {
+		t.Errorf("unexpected Sum signature %s", sum.Signature())
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		message string
+	}{
+		{"unknown type", "facts:\n  Order: OrderFact\n", `facts.yaml:2:10: unknown type "OrderFact"`},
+		{"unknown key", "fact:\n  Order: string\n", `unknown key "fact"`},
+		{"variadic not last", "types:\n  T:\n    methods:\n      M:\n        params: [...int, string]\n", "only the last parameter can be variadic"},
+		{"shadowed built-in", "types:\n  string:\n    fields: {}\n", "shadows a built-in type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse("facts.yaml", []byte(tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.message) {
+				t.Errorf("expected error containing %q, got %v", tt.message, err)
+			}
+		})
+	}
+}
+
+func TestLoad_JSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "facts.json")
+	content := `{"facts": {"Order": {"fields": {"Total": "float"}}}}`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	order, ok := s.Fact("Order")
+	if !ok {
+		t.Fatal("expected fact Order")
+	}
+	if _, ok := order.Field("Total"); !ok {
+		t.Error("expected field Order.Total")
+	}
+}
@@ -0,0 +1,43 @@
+// Package suggest finds likely intended values for misspelled names.
+package suggest
+
+import "strings"
+
+// maxDistance is the largest edit distance still considered a typo.
+const maxDistance = 2
+
+// Closest returns the candidate closest to value, ignoring case, if it is
+// within a small edit distance and differs from value.
+func Closest(value string, candidates []string) (string, bool) {
+	best, bestDistance := "", maxDistance+1
+	for _, c := range candidates {
+		if d := Distance(strings.ToLower(value), strings.ToLower(c)); d < bestDistance {
+			best, bestDistance = c, d
+		}
+	}
+	if best == "" || best == value {
+		return "", false
+	}
+	return best, true
+}
+
+// Distance returns the Levenshtein distance between two strings.
+func Distance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr := make([]int, len(b)+1)
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev = curr
+	}
+	return prev[len(b)]
+}
@@ -1,7 +1,11 @@
 package lint
 
 import (
+	"fmt"
+
 	"github.com/adarshjos/grule-lint/internal/config"
+	"github.com/adarshjos/grule-lint/internal/rules"
+	"github.com/adarshjos/grule-lint/internal/schema"
 )
 
 // ConfigFileName is the default configuration file name.
@@ -10,6 +14,9 @@ const ConfigFileName = config.ConfigFileName
 // Config holds linting configuration options.
 type Config struct {
 	c *config.Config
+
+	// schema is the loaded fact schema named by c.Schema, if any.
+	schema *schema.Schema
 }
 
 // DefaultConfig returns a new Config with default settings.
@@ -17,13 +24,14 @@ func DefaultConfig() *Config {
 	return &Config{c: config.DefaultConfig()}
 }
 
-// LoadConfig loads configuration from the specified file path.
+// LoadConfig loads configuration from the specified file path. Unknown
+// keys are ignored; other invalid settings are returned as an error.
 func LoadConfig(path string) (*Config, error) {
 	c, err := config.Load(path)
 	if err != nil {
 		return nil, err
 	}
-	return &Config{c: c}, nil
+	return newConfig(c)
 }
 
 // LoadConfigFromDirectory searches for a .grl-lint.yaml file starting
@@ -33,7 +41,26 @@ func LoadConfigFromDirectory(dir string) (*Config, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &Config{c: c}, nil
+	return newConfig(c)
+}
+
+// newConfig wraps a loaded config, loading its fact schema if it has one.
+func newConfig(c *config.Config) (*Config, error) {
+	cfg := &Config{c: c}
+	if err := cfg.reloadSchema(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// reloadSchema loads the fact schema described by the config.
+func (c *Config) reloadSchema() error {
+	s, err := c.c.LoadSchema()
+	if err != nil {
+		return fmt.Errorf("loading schema: %w", err)
+	}
+	c.schema = s
+	return nil
 }
 
 // Rules returns the rule severity overrides.
@@ -47,6 +74,125 @@ func (c *Config) SetRule(ruleID, severity string) {
 	c.c.Rules[ruleID] = severity
 }
 
+// RuleOptions returns the options set for a rule, keyed by option name.
+func (c *Config) RuleOptions(ruleID string) map[string]any {
+	return c.c.RuleOptions[ruleID]
+}
+
+// SetRuleOption sets an option of a configurable rule, such as
+// "max-conditions" for GRL006. It returns an error if the rule does not
+// declare the option or the value is invalid.
+func (c *Config) SetRuleOption(ruleID, name string, value any) error {
+	rule, ok := rules.DefaultRegistry().GetRule(ruleID).(rules.ConfigurableRule)
+	if !ok {
+		return fmt.Errorf("%s does not accept options", ruleID)
+	}
+	opt, ok := rules.FindOption(rule, name)
+	if !ok {
+		return fmt.Errorf("%s does not have an option %q", ruleID, name)
+	}
+	if _, err := opt.Check(value); err != nil {
+		return fmt.Errorf("%s: %w", ruleID, err)
+	}
+
+	if c.c.RuleOptions == nil {
+		c.c.RuleOptions = make(map[string]map[string]any)
+	}
+	if c.c.RuleOptions[ruleID] == nil {
+		c.c.RuleOptions[ruleID] = make(map[string]any)
+	}
+	c.c.RuleOptions[ruleID][name] = value
+	return nil
+}
+
+// DataContext returns the fact names declared for the undefined-variable
+// rule (GRL009), or nil if its built-in list of common names is used.
+func (c *Config) DataContext() []string {
+	switch v := c.c.RuleOptions["GRL009"]["data-context"].(type) {
+	case []string:
+		return v
+	case []any:
+		// Lists loaded from YAML are untyped
+		names := make([]string, 0, len(v))
+		for _, item := range v {
+			if name, ok := item.(string); ok {
+				names = append(names, name)
+			}
+		}
+		return names
+	}
+	return nil
+}
+
+// SetDataContext declares the fact names added to the DataContext, so the
+// undefined-variable rule (GRL009) reports any other name. Like
+// SetRuleOption, it returns an error if the option is rejected.
+func (c *Config) SetDataContext(names []string) error {
+	return c.SetRuleOption("GRL009", "data-context", names)
+}
+
+// Schema returns the path of the fact schema file, or an empty string if
+// none is set.
+func (c *Config) Schema() string {
+	return c.c.Schema
+}
+
+// SetSchema loads a fact schema file (YAML or JSON) describing the facts in
+// the DataContext. Its fact names are known to the undefined-variable rule,
+// and their fields, methods and arguments are checked.
+func (c *Config) SetSchema(path string) error {
+	previous := c.c.Schema
+	c.c.Schema = path
+	if err := c.reloadSchema(); err != nil {
+		c.c.Schema = previous
+		return err
+	}
+	return nil
+}
+
+// SetGoFacts derives fact schemas from Go struct types declared in the
+// package in dir, type-checked from source. Facts maps DataContext names
+// to type names, e.g. {"Order": "OrderFact"}. Facts from a schema file
+// take precedence.
+func (c *Config) SetGoFacts(dir string, facts map[string]string) error {
+	previous := c.c.GoFacts
+	c.c.GoFacts = config.GoFactsConfig{Package: dir, Facts: facts}
+	if err := c.reloadSchema(); err != nil {
+		c.c.GoFacts = previous
+		return err
+	}
+	return nil
+}
+
+// KnowledgeBase describes files an application loads into one Grule
+// knowledge base under the same name and version.
+type KnowledgeBase struct {
+	Name    string
+	Version string
+	Files   []string // glob patterns
+}
+
+// KnowledgeBases returns the knowledge bases whose files are checked
+// together.
+func (c *Config) KnowledgeBases() []KnowledgeBase {
+	kbs := make([]KnowledgeBase, 0, len(c.c.KnowledgeBases))
+	for _, kb := range c.c.KnowledgeBases {
+		kbs = append(kbs, KnowledgeBase{Name: kb.Name, Version: kb.Version, Files: kb.Files})
+	}
+	return kbs
+}
+
+// AddKnowledgeBase declares files loaded into one knowledge base, so that
+// LintPaths also reports duplicate rules, conflicting rules and build
+// errors across them. A knowledge base with the same name is replaced.
+func (c *Config) AddKnowledgeBase(kb KnowledgeBase) {
+	c.c.Merge(&config.Config{KnowledgeBases: []config.KnowledgeBaseConfig{{
+		Name:    kb.Name,
+		Version: kb.Version,
+		Files:   kb.Files,
+	}}})
+}
+
 // Exclude returns the list of exclude patterns.
 func (c *Config) Exclude() []string {
 	return c.c.Exclude
@@ -72,6 +218,16 @@ func (c *Config) SetInclude(patterns []string) {
 	c.c.Include = patterns
 }
 
+// Gitignore returns whether files ignored by .gitignore are skipped.
+func (c *Config) Gitignore() bool {
+	return c.c.Gitignore
+}
+
+// SetGitignore sets whether files ignored by .gitignore are skipped.
+func (c *Config) SetGitignore(enabled bool) {
+	c.c.Gitignore = enabled
+}
+
 // MaxConditions returns the maximum number of conditions allowed
 // before the high-complexity rule triggers.
 func (c *Config) MaxConditions() int {
@@ -110,5 +266,8 @@ func (c *Config) ShouldExclude(file string) bool {
 func (c *Config) Merge(other *Config) {
 	if other != nil {
 		c.c.Merge(other.c)
+		if other.schema != nil {
+			c.schema = other.schema
+		}
 	}
 }
@@ -2,6 +2,8 @@ package lint
 
 import (
 	"github.com/adarshjos/grule-lint/internal/diagnostic"
+	"github.com/adarshjos/grule-lint/internal/reporter"
+	"github.com/adarshjos/grule-lint/internal/rules"
 )
 
 // Severity represents the severity level of a diagnostic.
@@ -80,6 +82,11 @@ func (d Diagnostic) Fixes() []Fix {
 // Result is a collection of diagnostics from a lint operation.
 type Result struct {
 	ds *diagnostic.DiagnosticSet
+
+	// sources and registry let reporters embed file contents and describe
+	// the rules that produced the diagnostics.
+	sources  reporter.SourceLookup
+	registry *rules.Registry
 }
 
 // All returns all diagnostics in the result.
@@ -37,6 +37,22 @@
 //	    }
 //	`)
 //
+// # Reporting
+//
+// Render a Result in any of the CLI's output formats, or register your own:
+//
+//	rep, err := lint.NewReporter("sarif", os.Stdout, lint.ReporterOptions{})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	if err := rep.Report(result); err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	lint.RegisterReporter("count", func(w io.Writer, opts lint.ReporterOptions) (lint.Reporter, error) {
+//	    return myCountReporter{w: w}, nil
+//	})
+//
 // # Severity Levels
 //
 // Diagnostics have four severity levels:
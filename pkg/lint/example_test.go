@@ -2,6 +2,7 @@ package lint_test
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/adarshjos/grule-lint/pkg/lint"
 )
@@ -86,7 +87,7 @@ rule RuleA salience 10 {
 	// Get diagnostics sorted by file, line, column
 	sorted := result.Sorted()
 	fmt.Printf("Got %d diagnostics (sorted)\n", len(sorted))
-	// Output: Got 7 diagnostics (sorted)
+	// Output: Got 9 diagnostics (sorted)
 }
 
 func ExampleAvailableRules() {
@@ -125,3 +126,27 @@ func ExampleConfig_SetRule() {
 	// GRL003 enabled: false
 	// GRL004 enabled: true
 }
+
+func ExampleNewReporter() {
+	linter := lint.New()
+
+	result := linter.LintString("example.grl", `
+rule NoRetract "Missing retract" salience 10 {
+    when
+        Order.Status == "pending"
+    then
+        Order.Status = "processing";
+}
+`)
+
+	// Render the result in the same format as "grule-lint --format github"
+	rep, err := lint.NewReporter("github", os.Stdout, lint.ReporterOptions{})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	if err := rep.Report(result); err != nil {
+		fmt.Println(err)
+	}
+	// Output: ::warning file=example.grl,line=5,col=5,endLine=5,endColumn=5,title=GRL004 missing-retract::Rule 'NoRetract' does not call Retract() - this may cause an infinite loop
+}
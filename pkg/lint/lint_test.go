@@ -1,6 +1,12 @@
 package lint_test
 
 import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/adarshjos/grule-lint/pkg/lint"
@@ -264,3 +270,226 @@ rule my_rule "desc" salience 10 {
 		}
 	}
 }
+
+func TestConfig_SetRuleOption(t *testing.T) {
+	cfg := lint.DefaultConfig()
+	if err := cfg.SetRuleOption("GRL006", "max-conditions", 1); err != nil {
+		t.Fatalf("SetRuleOption failed: %v", err)
+	}
+	if err := cfg.SetRuleOption("GRL006", "max-conditions", 0); err == nil {
+		t.Error("expected an error for max-conditions below 1")
+	}
+	if err := cfg.SetRuleOption("GRL006", "unknown", 1); err == nil {
+		t.Error("expected an error for an unknown option")
+	}
+	if err := cfg.SetRuleOption("GRL002", "max-conditions", 1); err == nil {
+		t.Error("expected an error for a rule without options")
+	}
+
+	linter := lint.NewWithConfig(cfg)
+	result := linter.LintString("test.grl", `
+rule TwoConditions "desc" salience 10 {
+    when Order.Total > 100 && Order.Status == "new"
+    then Retract("TwoConditions");
+}
+`)
+
+	found := false
+	for _, d := range result.All() {
+		if d.RuleID() == "GRL006" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected GRL006 with max-conditions=1")
+	}
+}
+
+func TestConfig_SetDataContext(t *testing.T) {
+	cfg := lint.DefaultConfig()
+	cfg.SetRule("GRL009", "error")
+	if err := cfg.SetDataContext([]string{"Claim"}); err != nil {
+		t.Fatalf("SetDataContext failed: %v", err)
+	}
+
+	if got := cfg.DataContext(); len(got) != 1 || got[0] != "Claim" {
+		t.Errorf("DataContext() = %v, want [Claim]", got)
+	}
+
+	linter := lint.NewWithConfig(cfg)
+	result := linter.LintString("test.grl", `
+rule CheckClaim "desc" salience 10 {
+    when Claim.Amount > 100 && Order.Total > 5
+    then Retract("CheckClaim");
+}
+`)
+
+	var undefined []string
+	for _, d := range result.All() {
+		if d.RuleID() == "GRL009" {
+			undefined = append(undefined, d.Message())
+		}
+	}
+	if len(undefined) != 1 || !strings.Contains(undefined[0], "'Order'") {
+		t.Errorf("Expected GRL009 only for Order, got %v", undefined)
+	}
+}
+
+func TestLintPaths_KnowledgeBase(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"pricing.grl": `
+rule ApplyDiscount "desc" salience 10 {
+    when Order.Total > 100
+    then Order.Discount = 10; Retract("ApplyDiscount");
+}
+`,
+		"shared.grl": `
+rule ApplyDiscount "desc" salience 10 {
+    when Order.Total > 200
+    then Order.Discount = 20; Retract("ApplyDiscount");
+}
+
+rule FreeShipping "desc" salience 5 {
+    when Order.Total > 100
+    then Order.Shipping = 0; Retract("FreeShipping");
+}
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	countByRule := func(cfg *lint.Config) map[string]int {
+		result, err := lint.NewWithConfig(cfg).LintPaths([]string{dir})
+		if err != nil {
+			t.Fatalf("LintPaths failed: %v", err)
+		}
+		counts := make(map[string]int)
+		for _, d := range result.All() {
+			counts[d.RuleID()]++
+		}
+		return counts
+	}
+
+	if counts := countByRule(lint.DefaultConfig()); counts["GRL005"] != 0 || counts["GRL012"] != 0 {
+		t.Errorf("Expected no cross-file issues without knowledge bases, got %v", counts)
+	}
+
+	cfg := lint.DefaultConfig()
+	cfg.AddKnowledgeBase(lint.KnowledgeBase{Name: "Pricing", Files: []string{"**/*.grl"}})
+	if kbs := cfg.KnowledgeBases(); len(kbs) != 1 || kbs[0].Name != "Pricing" {
+		t.Errorf("KnowledgeBases() = %v", kbs)
+	}
+
+	counts := countByRule(cfg)
+	if counts["GRL005"] != 1 {
+		t.Errorf("Expected GRL005 for ApplyDiscount, got %v", counts)
+	}
+	if counts["GRL012"] != 1 {
+		t.Errorf("Expected GRL012 for FreeShipping, got %v", counts)
+	}
+
+	// Overrides apply to findings across files like to any other
+	configPath := filepath.Join(t.TempDir(), ".grl-lint.yaml")
+	configYAML := `knowledge_bases:
+  - name: Pricing
+    files: ["**/*.grl"]
+overrides:
+  - files: ["**/shared.grl"]
+    rules:
+      GRL005: "off"
+      GRL012: error
+`
+	if err := os.WriteFile(configPath, []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := lint.LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	result, err := lint.NewWithConfig(cfg).LintPaths([]string{dir})
+	if err != nil {
+		t.Fatalf("LintPaths failed: %v", err)
+	}
+	var conflicts int
+	for _, d := range result.All() {
+		switch d.RuleID() {
+		case "GRL005":
+			t.Errorf("Expected the override to turn off GRL005, got %s", d.Message())
+		case "GRL012":
+			conflicts++
+			if d.Severity() != lint.SeverityError {
+				t.Errorf("Expected the override to make GRL012 an error, got %v", d.Severity())
+			}
+		}
+	}
+	if conflicts != 1 {
+		t.Errorf("Expected GRL012 for FreeShipping, got %d", conflicts)
+	}
+}
+
+func TestNewReporter_BuiltinFormats(t *testing.T) {
+	linter := lint.New()
+	result := linter.LintString("test.grl", `
+rule NoRetract "Missing retract" salience 10 {
+    when
+        Order.Status == "pending"
+    then
+        Order.Status = "processing";
+}
+`)
+
+	for _, format := range lint.ReporterFormats() {
+		if format == "template" {
+			continue
+		}
+		var buf bytes.Buffer
+		rep, err := lint.NewReporter(format, &buf, lint.ReporterOptions{})
+		if err != nil {
+			t.Errorf("%s: NewReporter failed: %v", format, err)
+			continue
+		}
+		if err := rep.Report(result); err != nil {
+			t.Errorf("%s: Report failed: %v", format, err)
+		}
+		if !strings.Contains(buf.String(), "GRL004") {
+			t.Errorf("%s: expected GRL004 in output, got:\n%s", format, buf.String())
+		}
+	}
+
+	if _, err := lint.NewReporter("unknown", &bytes.Buffer{}, lint.ReporterOptions{}); err == nil {
+		t.Error("Expected error for unknown format")
+	}
+}
+
+type ruleCountReporter struct {
+	w io.Writer
+}
+
+func (r *ruleCountReporter) Format() string { return "rule-count" }
+
+func (r *ruleCountReporter) Report(result *lint.Result) error {
+	_, err := fmt.Fprintf(r.w, "%d issues", result.Count())
+	return err
+}
+
+func TestRegisterReporter(t *testing.T) {
+	lint.RegisterReporter("rule-count", func(w io.Writer, opts lint.ReporterOptions) (lint.Reporter, error) {
+		return &ruleCountReporter{w: w}, nil
+	})
+
+	var buf bytes.Buffer
+	rep, err := lint.NewReporter("rule-count", &buf, lint.ReporterOptions{})
+	if err != nil {
+		t.Fatalf("NewReporter failed: %v", err)
+	}
+	if err := rep.Report(lint.New().LintString("empty.grl", "")); err != nil {
+		t.Fatalf("Report failed: %v", err)
+	}
+	if buf.String() != "0 issues" {
+		t.Errorf("Expected '0 issues', got %q", buf.String())
+	}
+}
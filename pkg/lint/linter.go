@@ -1,6 +1,8 @@
 package lint
 
 import (
+	"github.com/adarshjos/grule-lint/internal/diagnostic"
+	"github.com/adarshjos/grule-lint/internal/discovery"
 	"github.com/adarshjos/grule-lint/internal/linter"
 	"github.com/adarshjos/grule-lint/internal/rules"
 )
@@ -25,14 +27,21 @@ func NewWithConfig(cfg *Config) *Linter {
 		cfg = DefaultConfig()
 	}
 
-	// Create registry config from the public config
-	regCfg := rules.RegistryConfig{
-		NamingConvention: cfg.NamingConvention(),
-		MaxConditions:    cfg.MaxConditions(),
+	// Create registry config from the public config, applying any
+	// path-specific overrides
+	resolve := func(file string) rules.RegistryConfig {
+		fileCfg := cfg.c.ForFile(file)
+		return rules.RegistryConfig{
+			NamingConvention: fileCfg.Naming.Convention,
+			MaxConditions:    fileCfg.Complexity.MaxConditions,
+			RuleOptions:      fileCfg.RuleOptions,
+			Rules:            fileCfg.Rules,
+			Schema:           cfg.schema,
+		}
 	}
 
 	return &Linter{
-		l:      linter.NewWithConfig(regCfg),
+		l:      linter.NewWithConfigResolver(resolve),
 		config: cfg,
 	}
 }
@@ -43,56 +52,54 @@ func (l *Linter) Config() *Config {
 }
 
 // LintFile lints a single GRL file and returns the results.
+// The file is linted regardless of the config's include patterns, but
+// excluded files and files ignored by .grl-lintignore (or .gitignore, if
+// enabled) produce an empty result, as they do for LintPaths.
 func (l *Linter) LintFile(file string) (*Result, error) {
-	if l.config.ShouldExclude(file) {
-		return &Result{ds: nil}, nil
-	}
-
-	ds, err := l.l.LintFile(file)
-	if err != nil {
-		return nil, err
-	}
-	return wrapDiagnosticSet(ds), nil
+	return l.LintPaths([]string{file})
 }
 
 // LintString lints GRL content from a string.
 // The file parameter is used for diagnostic reporting.
 func (l *Linter) LintString(file, content string) *Result {
 	ds := l.l.LintString(file, content)
-	return wrapDiagnosticSet(ds)
+	return l.wrap(ds)
 }
 
-// LintFiles lints multiple GRL files.
+// LintFiles lints multiple GRL files. Like LintFile, it skips excluded
+// and ignored files.
 func (l *Linter) LintFiles(files []string) (*Result, error) {
-	// Filter excluded files
-	var filtered []string
-	for _, f := range files {
-		if !l.config.ShouldExclude(f) {
-			filtered = append(filtered, f)
-		}
-	}
-
-	ds, err := l.l.LintFiles(filtered)
-	if err != nil {
-		return nil, err
-	}
-	return wrapDiagnosticSet(ds), nil
+	return l.LintPaths(files)
 }
 
 // LintDirectory lints all GRL files in a directory recursively.
 func (l *Linter) LintDirectory(dir string) (*Result, error) {
-	ds, err := l.l.LintDirectory(dir)
-	if err != nil {
-		return nil, err
-	}
-	return wrapDiagnosticSet(ds), nil
+	return l.LintPaths([]string{dir})
 }
 
-// LintPaths lints files and/or directories.
+// LintPaths lints files and/or directories. Files in directories must match
+// the config's include patterns; excluded files and files listed in
+// .grl-lintignore are skipped.
 func (l *Linter) LintPaths(paths []string) (*Result, error) {
+	l.l.SetFinder(discovery.New(discovery.Options{
+		Include:   l.config.Include(),
+		Exclude:   l.config.Exclude(),
+		Gitignore: l.config.Gitignore(),
+	}))
+	l.l.SetKnowledgeBases(l.config.c.KnowledgeBases)
+
 	ds, err := l.l.LintPaths(paths)
 	if err != nil {
 		return nil, err
 	}
-	return wrapDiagnosticSet(ds), nil
+	return l.wrap(ds), nil
+}
+
+// wrap wraps an internal DiagnosticSet as a Result that reporters can render
+// with this linter's sources and rules.
+func (l *Linter) wrap(ds *diagnostic.DiagnosticSet) *Result {
+	result := wrapDiagnosticSet(ds)
+	result.sources = l.l.Source
+	result.registry = l.l.Registry()
+	return result
 }
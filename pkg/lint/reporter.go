@@ -0,0 +1,130 @@
+package lint
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"text/template"
+
+	"github.com/adarshjos/grule-lint/internal/reporter"
+)
+
+// Reporter renders lint results in a particular output format.
+type Reporter interface {
+	// Format returns the name of the output format (e.g., "json").
+	Format() string
+
+	// Report writes the result.
+	Report(result *Result) error
+}
+
+// ReporterOptions configures a Reporter created by NewReporter.
+// Reporters ignore the options they do not use.
+type ReporterOptions struct {
+	// Colorized enables ANSI colors for the text and pretty formats.
+	Colorized bool
+
+	// ToolVersion is the grule-lint version recorded in SARIF output.
+	ToolVersion string
+
+	// Template is the template used by the template format.
+	Template *template.Template
+}
+
+// ReporterFactory creates a Reporter that writes to w.
+type ReporterFactory func(w io.Writer, opts ReporterOptions) (Reporter, error)
+
+var (
+	reportersMu     sync.RWMutex
+	reporterFormats []string
+	reporters       = make(map[string]ReporterFactory)
+)
+
+func init() {
+	builtins := reporter.DefaultRegistry()
+	for _, format := range builtins.Formats() {
+		RegisterReporter(format, builtinFactory(builtins, format))
+	}
+}
+
+// RegisterReporter registers a reporter factory for an output format,
+// replacing any existing factory with the same name. Built-in formats
+// are the same as the grule-lint CLI's --format values.
+func RegisterReporter(format string, factory ReporterFactory) {
+	reportersMu.Lock()
+	defer reportersMu.Unlock()
+
+	if _, exists := reporters[format]; !exists {
+		reporterFormats = append(reporterFormats, format)
+	}
+	reporters[format] = factory
+}
+
+// ReporterFormats returns the names of all registered output formats.
+func ReporterFormats() []string {
+	reportersMu.RLock()
+	defer reportersMu.RUnlock()
+
+	formats := make([]string, len(reporterFormats))
+	copy(formats, reporterFormats)
+	return formats
+}
+
+// NewReporter creates a Reporter for the given output format.
+func NewReporter(format string, w io.Writer, opts ReporterOptions) (Reporter, error) {
+	reportersMu.RLock()
+	factory, ok := reporters[format]
+	reportersMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unknown output format %q", format)
+	}
+	return factory(w, opts)
+}
+
+// builtinReporter adapts an internal reporter format to the public
+// Reporter interface. The internal reporter is created per Report call so
+// it can use the sources and rules of the linter that produced the result.
+type builtinReporter struct {
+	registry *reporter.Registry
+	format   string
+	writer   io.Writer
+	opts     ReporterOptions
+}
+
+func builtinFactory(registry *reporter.Registry, format string) ReporterFactory {
+	return func(w io.Writer, opts ReporterOptions) (Reporter, error) {
+		if format == "template" && opts.Template == nil {
+			return nil, fmt.Errorf("template format requires ReporterOptions.Template")
+		}
+		return &builtinReporter{
+			registry: registry,
+			format:   format,
+			writer:   w,
+			opts:     opts,
+		}, nil
+	}
+}
+
+func (r *builtinReporter) Format() string {
+	return r.format
+}
+
+func (r *builtinReporter) Report(result *Result) error {
+	if result == nil {
+		result = wrapDiagnosticSet(nil)
+	}
+
+	rep, err := r.registry.New(r.format, reporter.Options{
+		Writer:      r.writer,
+		Colorized:   r.opts.Colorized,
+		Sources:     result.sources,
+		Registry:    result.registry,
+		ToolVersion: r.opts.ToolVersion,
+		Template:    r.opts.Template,
+	})
+	if err != nil {
+		return err
+	}
+	return rep.Report(result.ds)
+}
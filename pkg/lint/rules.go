@@ -83,6 +83,48 @@ func AvailableRules() []RuleInfo {
 			Description: "Rule is unreachable due to conflicting conditions",
 			Severity:    SeverityWarning,
 		},
+		{
+			ID:          "GRL013",
+			Name:        "unused-suppression",
+			Description: "Suppression comment does not silence any issue",
+			Severity:    SeverityWarning,
+		},
+		{
+			ID:          "GRL014",
+			Name:        "unknown-field",
+			Description: "Field is not declared in the fact schema",
+			Severity:    SeverityError,
+		},
+		{
+			ID:          "GRL015",
+			Name:        "unknown-method",
+			Description: "Method is not declared in the fact schema",
+			Severity:    SeverityError,
+		},
+		{
+			ID:          "GRL016",
+			Name:        "argument-count",
+			Description: "Method call has the wrong number of arguments for the fact schema",
+			Severity:    SeverityError,
+		},
+		{
+			ID:          "GRL018",
+			Name:        "retract-mismatch",
+			Description: "Retract() should name the enclosing rule or an existing rule",
+			Severity:    SeverityWarning,
+		},
+		{
+			ID:          "GRL019",
+			Name:        "infinite-loop",
+			Description: "Rule cannot make its own condition false and never retracts",
+			Severity:    SeverityWarning,
+		},
+		{
+			ID:          "GRL020",
+			Name:        "rule-cycle",
+			Description: "Rules that do not retract trigger each other in a cycle",
+			Severity:    SeverityWarning,
+		},
 	}
 }
 
@@ -381,3 +381,163 @@ func TestLinter_PositionAccuracy(t *testing.T) {
 		}
 	}
 }
+
+// TestLinter_SuppressionComments tests inline suppression directives
+func TestLinter_SuppressionComments(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		ruleID   string
+		expected bool
+	}{
+		{
+			name: "next line",
+			content: `// grule-lint-disable-next-line GRL002
+rule NoDesc salience 1 {
+    when Order.Status == "pending"
+    then Retract("NoDesc");
+}`,
+			ruleID:   "GRL002",
+			expected: false,
+		},
+		{
+			name: "next line other rule",
+			content: `// grule-lint-disable-next-line GRL003
+rule NoDesc salience 1 {
+    when Order.Status == "pending"
+    then Retract("NoDesc");
+}`,
+			ruleID:   "GRL002",
+			expected: true,
+		},
+		{
+			name: "next line above rule header covers the rule",
+			content: `// grule-lint-disable-next-line GRL004
+rule ProcessOrder "Process pending orders" salience 1 {
+    when Order.Status == "pending"
+    then Order.Status = "processing";
+}`,
+			ruleID:   "GRL004",
+			expected: false,
+		},
+		{
+			name: "next line above rule header is used",
+			content: `// grule-lint-disable-next-line GRL004
+rule ProcessOrder "Process pending orders" salience 1 {
+    when Order.Status == "pending"
+    then Order.Status = "processing";
+}`,
+			ruleID:   "GRL013",
+			expected: false,
+		},
+		{
+			name: "block",
+			content: `/* grule-lint-disable GRL004 */
+rule First "Test" salience 1 {
+    when Order.Status == "pending"
+    then Log("first");
+}
+// grule-lint-enable GRL004
+rule Second "Test" salience 1 {
+    when Order.Status == "done"
+    then Log("second");
+}`,
+			ruleID:   "GRL004",
+			expected: true,
+		},
+		{
+			name: "file",
+			content: `// grule-lint-disable-file GRL004 -- legacy rules
+rule First "Test" salience 1 {
+    when Order.Status == "pending"
+    then Log("first");
+}`,
+			ruleID:   "GRL004",
+			expected: false,
+		},
+		{
+			name: "directive inside string is ignored",
+			content: `rule NoRetract "// grule-lint-disable-file" salience 1 {
+    when Order.Status == "pending"
+    then Log("no retract");
+}`,
+			ruleID:   "GRL004",
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := linter.New()
+			ds := l.LintString("test.grl", tt.content)
+
+			if got := hasRuleID(ds, tt.ruleID); got != tt.expected {
+				t.Errorf("%s reported = %v, want %v", tt.ruleID, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestLinter_SuppressionBlockScope tests that enable ends a disable block
+func TestLinter_SuppressionBlockScope(t *testing.T) {
+	l := linter.New()
+
+	content := `// grule-lint-disable GRL004
+rule First "Test" salience 1 {
+    when Order.Status == "pending"
+    then Log("first");
+}
+// grule-lint-enable
+rule Second "Test" salience 1 {
+    when Order.Status == "done"
+    then Log("second");
+}`
+	ds := l.LintString("test.grl", content)
+
+	var lines []int
+	for _, d := range ds.All() {
+		if d.RuleID == "GRL004" {
+			lines = append(lines, d.Range.Start.Line)
+		}
+	}
+	if len(lines) != 1 || lines[0] != 9 {
+		t.Errorf("Expected a single GRL004 on line 9, got lines %v", lines)
+	}
+	if hasRuleID(ds, "GRL013") {
+		t.Error("Expected no unused suppression")
+	}
+}
+
+// TestLinter_UnusedSuppression tests GRL013
+func TestLinter_UnusedSuppression(t *testing.T) {
+	l := linter.New()
+
+	content := `rule Valid "Test" salience 1 {
+    // grule-lint-disable-next-line GRL004
+    when Order.Status == "pending"
+    then Retract("Valid");
+}`
+	ds := l.LintString("test.grl", content)
+
+	var unused []diagnostic.Diagnostic
+	for _, d := range ds.All() {
+		if d.RuleID == "GRL013" {
+			unused = append(unused, d)
+		}
+	}
+	if len(unused) != 1 {
+		t.Fatalf("Expected 1 GRL013 diagnostic, got %d", len(unused))
+	}
+
+	d := unused[0]
+	if d.Range.Start.Line != 2 || d.Range.Start.Column != 5 {
+		t.Errorf("Expected GRL013 at 2:5, got %s", d.Range.Start)
+	}
+	if len(d.Fixes) != 1 || len(d.Fixes[0].Edits) != 1 {
+		t.Fatalf("Expected a fix removing the comment, got %+v", d.Fixes)
+	}
+	edit := d.Fixes[0].Edits[0]
+	if edit.Range.Start.Line != 2 || edit.Range.Start.Column != 1 || edit.Range.End.Line != 3 || edit.NewText != "" {
+		t.Errorf("Expected fix to delete line 2, got %+v", edit)
+	}
+}
@@ -1,9 +1,16 @@
 package test
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/adarshjos/grule-lint/internal/config"
+	"github.com/adarshjos/grule-lint/internal/diagnostic"
 	"github.com/adarshjos/grule-lint/internal/linter"
+	"github.com/adarshjos/grule-lint/internal/rules"
+	"github.com/adarshjos/grule-lint/internal/schema"
 )
 
 // TestRules_Detection uses table-driven tests to verify each rule detects issues correctly.
@@ -134,6 +141,76 @@ rule AlwaysTrue "Test" salience 1 {
 			expectRule:  "GRL010",
 			shouldExist: true,
 		},
+		{
+			name: "GRL019_InfiniteLoop",
+			grl: `
+rule ApplyDiscount "Test" salience 1 {
+    when Order.Total > 100
+    then Order.Discount = 10;
+}`,
+			expectRule:  "GRL019",
+			shouldExist: true,
+		},
+		{
+			name: "GRL019_InfiniteLoop_AlwaysTrue",
+			grl: `
+rule AlwaysTrue "Test" salience 1 {
+    when true
+    then Log("fired");
+}`,
+			expectRule:  "GRL019",
+			shouldExist: true,
+		},
+		{
+			name: "GRL019_WriteFalsifiesCondition_NoTrigger",
+			grl: `
+rule MarkProcessed "Test" salience 1 {
+    when Order.Status == "new"
+    then Order.Status = "processed";
+}`,
+			expectRule:  "GRL019",
+			shouldExist: false,
+		},
+		{
+			name: "GRL019_MethodMayChangeReceiver_NoTrigger",
+			grl: `
+rule ClearItems "Test" salience 1 {
+    when Order.Items[0].Quantity > 1
+    then Order.Items.Clear();
+}`,
+			expectRule:  "GRL019",
+			shouldExist: false,
+		},
+		{
+			name: "GRL019_Complete_NoTrigger",
+			grl: `
+rule Finish "Test" salience 1 {
+    when Order.Total > 100
+    then Order.Discount = 10; Complete();
+}`,
+			expectRule:  "GRL019",
+			shouldExist: false,
+		},
+		{
+			name: "GRL019_RetractsAnotherRuleOnly",
+			grl: `
+rule ApplyDiscount "Test" salience 1 {
+    when Fact.D > 1
+    then Fact.E = 2; Retract("Typo");
+}`,
+			expectRule:  "GRL019",
+			shouldExist: true,
+		},
+		{
+			name: "GRL019_RetractsItself_NoTrigger",
+			grl: `
+rule ApplyDiscount "Test" salience 1 {
+    when Fact.D > 1
+    then Fact.E = 2; Retract("ApplyDiscount");
+}`,
+			expectRule:  "GRL019",
+			shouldExist: false,
+		},
 	}
 
 	l := linter.New()
@@ -205,3 +282,493 @@ rule ValidRule "Description" salience 10 {
 		})
 	}
 }
+
+// TestRules_DataContext tests GRL009 with DataContext names from the config.
+func TestRules_DataContext(t *testing.T) {
+	l := linter.NewWithConfig(rules.RegistryConfig{
+		RuleOptions: map[string]map[string]any{
+			"GRL009": {"data-context": []any{"Claim", "Env"}},
+		},
+	})
+
+	tests := []struct {
+		name        string
+		grl         string
+		shouldExist bool
+	}{
+		{
+			name: "Declared",
+			grl: `
+rule Declared "desc" salience 1 {
+    when Claim.Amount > Env.Limit
+    then Retract("Declared");
+}`,
+			shouldExist: false,
+		},
+		{
+			name: "BuiltInGuessNotDeclared",
+			grl: `
+rule Guess "desc" salience 1 {
+    when Order.Total > 100
+    then Retract("Guess");
+}`,
+			shouldExist: true,
+		},
+		{
+			name: "WrongCase",
+			grl: `
+rule WrongCase "desc" salience 1 {
+    when claim.Amount > 100
+    then Retract("WrongCase");
+}`,
+			shouldExist: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ds := l.LintString("test.grl", tt.grl)
+			if exists := hasRuleID(ds, "GRL009"); exists != tt.shouldExist {
+				t.Errorf("Expected GRL009=%v, got %v. Diagnostics: %v", tt.shouldExist, exists, ds.All())
+			}
+		})
+	}
+}
+
+// TestRules_FactSchema tests GRL014-GRL016 against a fact schema.
+func TestRules_FactSchema(t *testing.T) {
+	s, err := schema.Parse("facts.yaml", []byte(`
+facts:
+  Order: OrderFact
+types:
+  OrderFact:
+    fields:
+      Total: float
+      Status: string
+      Items: "[]Item"
+    methods:
+      AddItem:
+        params: [string, int]
+  Item:
+    fields:
+      Name: string
+`))
+	if err != nil {
+		t.Fatalf("parsing schema: %v", err)
+	}
+	l := linter.NewWithConfig(rules.RegistryConfig{Schema: s})
+
+	tests := []struct {
+		name        string
+		grl         string
+		expectRule  string
+		shouldExist bool
+	}{
+		{
+			name: "GRL014_UnknownField",
+			grl: `
+rule Typo "desc" salience 1 {
+    when Order.Totl > 100
+    then Retract("Typo");
+}`,
+			expectRule:  "GRL014",
+			shouldExist: true,
+		},
+		{
+			name: "GRL014_UnknownNestedField",
+			grl: `
+rule Nested "desc" salience 1 {
+    when Order.Items[0].Nme == "x"
+    then Retract("Nested");
+}`,
+			expectRule:  "GRL014",
+			shouldExist: true,
+		},
+		{
+			name: "GRL014_KnownFields_NoTrigger",
+			grl: `
+rule Known "desc" salience 1 {
+    when Order.Total > 100 && Order.Items[0].Name == "x"
+    then Order.Status = "big"; Retract("Known");
+}`,
+			expectRule:  "GRL014",
+			shouldExist: false,
+		},
+		{
+			name: "GRL015_UnknownMethod",
+			grl: `
+rule Unknown "desc" salience 1 {
+    when Order.Total > 100
+    then Order.RemoveItem("x"); Retract("Unknown");
+}`,
+			expectRule:  "GRL015",
+			shouldExist: true,
+		},
+		{
+			name: "GRL015_BuiltInMethod_NoTrigger",
+			grl: `
+rule BuiltIn "desc" salience 1 {
+    when Order.Status.Len() > 0
+    then Retract("BuiltIn");
+}`,
+			expectRule:  "GRL015",
+			shouldExist: false,
+		},
+		{
+			name: "GRL016_WrongArgumentCount",
+			grl: `
+rule Args "desc" salience 1 {
+    when Order.Total > 100
+    then Order.AddItem("x"); Retract("Args");
+}`,
+			expectRule:  "GRL016",
+			shouldExist: true,
+		},
+		{
+			name: "GRL016_RightArgumentCount_NoTrigger",
+			grl: `
+rule Args "desc" salience 1 {
+    when Order.Total > 100
+    then Order.AddItem("x", 1); Retract("Args");
+}`,
+			expectRule:  "GRL016",
+			shouldExist: false,
+		},
+		{
+			name: "GRL009_SchemaFact_NoTrigger",
+			grl: `
+rule Facts "desc" salience 1 {
+    when Order.Total > 100
+    then Retract("Facts");
+}`,
+			expectRule:  "GRL009",
+			shouldExist: false,
+		},
+		{
+			name: "GRL009_NotInSchema",
+			grl: `
+rule Facts "desc" salience 1 {
+    when Customer.Age > 18
+    then Retract("Facts");
+}`,
+			expectRule:  "GRL009",
+			shouldExist: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ds := l.LintString("test.grl", tt.grl)
+			if exists := hasRuleID(ds, tt.expectRule); exists != tt.shouldExist {
+				t.Errorf("Expected %s=%v, got %v. Diagnostics: %v", tt.expectRule, tt.shouldExist, exists, ds.All())
+			}
+		})
+	}
+}
+
+// TestRules_InvalidOptions tests that invalid rule options are reported
+// while the valid ones still apply.
+func TestRules_InvalidOptions(t *testing.T) {
+	l := linter.NewWithConfig(rules.RegistryConfig{
+		RuleOptions: map[string]map[string]any{
+			"GRL006": {"max-conditions": 1, "unknown": true},
+		},
+	})
+
+	path := filepath.Join(t.TempDir(), "test.grl")
+	content := `rule TwoConditions "desc" salience 1 {
+    when Order.Total > 1 && Order.Status == "new"
+    then Order.Total = 0; Retract("TwoConditions");
+}`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := l.LintFile(path); err == nil || !strings.Contains(err.Error(), `"unknown"`) {
+		t.Errorf("Expected an error for the unknown option, got %v", err)
+	}
+
+	if ds := l.LintString("test.grl", content); !hasRuleID(ds, "GRL006") {
+		t.Error("Expected max-conditions=1 to apply despite the invalid option")
+	}
+}
+
+// TestRules_OptionDefaults verifies that options that are not set get the
+// default they declare, and that invalid values are reported with the
+// option's description.
+func TestRules_OptionDefaults(t *testing.T) {
+	complexity := &rules.HighComplexityRule{MaxConditions: 2}
+	if err := rules.ConfigureRule(complexity, nil); err != nil {
+		t.Fatalf("ConfigureRule failed: %v", err)
+	}
+	if complexity.MaxConditions != rules.DefaultMaxConditions {
+		t.Errorf("Expected the default max-conditions %d, got %d", rules.DefaultMaxConditions, complexity.MaxConditions)
+	}
+
+	naming := &rules.NamingConventionRule{}
+	if err := rules.ConfigureRule(naming, map[string]any{"convention": "pascal"}); err == nil ||
+		!strings.Contains(err.Error(), "Naming convention rule names must follow") {
+		t.Errorf("Expected the error to describe the option, got %v", err)
+	}
+	if naming.Convention != rules.ConventionPascalCase {
+		t.Errorf("Expected the invalid convention to leave the default, got %q", naming.Convention)
+	}
+}
+
+// TestRules_DataContextNotShared verifies that GRL009 does not write into
+// the configured data-context names when facts are added.
+func TestRules_DataContextNotShared(t *testing.T) {
+	names := make([]string, 1, 2)
+	names[0] = "Claim"
+
+	first := rules.NewUndefinedVariableRule()
+	second := rules.NewUndefinedVariableRule()
+	for _, rule := range []*rules.UndefinedVariableRule{first, second} {
+		if err := rules.ConfigureRule(rule, map[string]any{"data-context": names}); err != nil {
+			t.Fatalf("ConfigureRule failed: %v", err)
+		}
+	}
+	first.AddFacts([]string{"Order"})
+	second.AddFacts([]string{"Policy"})
+
+	if got := first.KnownDataContextNames; len(got) != 2 || got[1] != "Order" {
+		t.Errorf("Expected [Claim Order], got %v", got)
+	}
+	if got := names[:cap(names)]; got[1] != "" {
+		t.Errorf("Expected the configured names to be left unchanged, got %v", got)
+	}
+}
+
+// TestRules_FactSchema_NoSchema tests that the schema rules are silent
+// without a schema.
+func TestRules_FactSchema_NoSchema(t *testing.T) {
+	l := linter.New()
+	ds := l.LintString("test.grl", `
+rule Typo "desc" salience 1 {
+    when Order.Totl > 100
+    then Order.AddItem(); Retract("Typo");
+}`)
+	for _, id := range []string{"GRL014", "GRL015", "GRL016"} {
+		if hasRuleID(ds, id) {
+			t.Errorf("Did not expect %s without a schema", id)
+		}
+	}
+}
+
+// projectRecorder is a project rule that records the project it checks.
+type projectRecorder struct {
+	project *rules.Project
+}
+
+func (r *projectRecorder) ID() string                           { return "TEST001" }
+func (r *projectRecorder) Name() string                         { return "project-recorder" }
+func (r *projectRecorder) Description() string                  { return "Records the project" }
+func (r *projectRecorder) DefaultSeverity() diagnostic.Severity { return diagnostic.SeverityInfo }
+
+func (r *projectRecorder) CheckProject(project *rules.Project) []diagnostic.Diagnostic {
+	r.project = project
+	return []diagnostic.Diagnostic{{
+		File:     project.Results[0].File,
+		RuleID:   r.ID(),
+		RuleName: r.Name(),
+		Severity: r.DefaultSeverity(),
+		Message:  "checked",
+	}}
+}
+
+// TestRules_ProjectRule verifies that project rules see every file of a
+// run, grouped by knowledge base, after the per-file rules.
+func TestRules_ProjectRule(t *testing.T) {
+	dir := t.TempDir()
+	var files []string
+	for _, name := range []string{"a.grl", "b.grl", "c.grl"} {
+		path := filepath.Join(dir, name)
+		content := `rule Rule_` + name[:1] + ` "desc" salience 1 { when true then Retract("Rule_` + name[:1] + `"); }`
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, path)
+	}
+
+	recorder := &projectRecorder{}
+	registry := rules.DefaultRegistry()
+	registry.RegisterProject(recorder)
+
+	l := linter.NewWithRegistry(registry)
+	l.SetKnowledgeBases([]config.KnowledgeBaseConfig{{Name: "AB", Files: []string{"a.grl", "b.grl"}}})
+
+	ds, err := l.LintPaths([]string{dir})
+	if err != nil {
+		t.Fatalf("LintPaths failed: %v", err)
+	}
+	if !hasRuleID(ds, "TEST001") {
+		t.Error("Expected the project rule's diagnostic")
+	}
+
+	project := recorder.project
+	if project == nil {
+		t.Fatal("Project rule did not run")
+	}
+	if len(project.Results) != 3 {
+		t.Errorf("Expected 3 parse results, got %d", len(project.Results))
+	}
+	if len(project.KnowledgeBases) != 1 || len(project.KnowledgeBases[0].Results) != 2 {
+		t.Fatalf("Expected knowledge base AB with 2 files, got %+v", project.KnowledgeBases)
+	}
+	if project.KnowledgeBases[0].KnowledgeBase == nil {
+		t.Error("Expected the knowledge base to be built")
+	}
+
+	var sizes []int
+	for _, set := range project.RuleSets() {
+		sizes = append(sizes, len(set))
+	}
+	if len(sizes) != 2 || sizes[0] != 2 || sizes[1] != 1 {
+		t.Errorf("Expected rule sets of 2 and 1 files, got %v", sizes)
+	}
+}
+
+var _ rules.ProjectRule = (*projectRecorder)(nil)
+
+// TestRules_RetractMismatch tests GRL018 on Retract calls naming another
+// rule or a rule that does not exist.
+func TestRules_RetractMismatch(t *testing.T) {
+	l := linter.New()
+	ds := l.LintString("test.grl", `
+rule NoDescription "desc" salience 1 {
+    when Order.Total > 1
+    then Retract("NoDescriptionn");
+}
+
+rule CopyPaste "desc" salience 1 {
+    when Order.Total > 2
+    then Retract("NoDescription");
+}
+
+rule Cleaner "desc" salience 1 {
+    when Order.Total > 3
+    then Retract('CopyPaste'); Retract("Cleaner");
+}`)
+
+	var got []diagnostic.Diagnostic
+	for _, d := range ds.All() {
+		if d.RuleID == "GRL018" {
+			got = append(got, d)
+		}
+	}
+	if len(got) != 2 {
+		t.Fatalf("Expected 2 GRL018 diagnostics, got %d: %v", len(got), got)
+	}
+
+	expected := []struct {
+		line    int
+		message string
+		fix     string
+	}{
+		{4, "retracts 'NoDescriptionn', which is not a rule", `"NoDescription"`},
+		{9, "retracts 'NoDescription' instead of itself", `"CopyPaste"`},
+	}
+	for i, e := range expected {
+		d := got[i]
+		if d.Range.Start.Line != e.line || !strings.Contains(d.Message, e.message) {
+			t.Errorf("diagnostic %d: expected line %d %q, got line %d %q", i, e.line, e.message, d.Range.Start.Line, d.Message)
+		}
+		if len(d.Fixes) != 1 || d.Fixes[0].Edits[0].NewText != e.fix {
+			t.Errorf("diagnostic %d: expected a fix to %s, got %+v", i, e.fix, d.Fixes)
+		}
+		if d.Range.Start.Column != 18 || d.Fixes[0].Edits[0].Range != d.Range {
+			t.Errorf("diagnostic %d: expected the fix to replace the literal at column 18, got %+v", i, d.Range)
+		}
+	}
+}
+
+// TestRules_RetractMismatch_KnowledgeBase tests that GRL018 resolves rule
+// names across the files of a knowledge base.
+func TestRules_RetractMismatch_KnowledgeBase(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.grl": `rule Reset "desc" salience 1 { when Order.Total > 1 then Retract("Audit"); Retract("Reset"); }`,
+		"b.grl": `rule Audit "desc" salience 1 { when Order.Total > 2 then Retract("Audit"); }`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	l := linter.New()
+	ds, err := l.LintPaths([]string{dir})
+	if err != nil {
+		t.Fatalf("LintPaths failed: %v", err)
+	}
+	if !hasRuleID(ds, "GRL018") {
+		t.Error("Expected GRL018 for a rule defined in a file outside the knowledge base")
+	}
+
+	l.SetKnowledgeBases([]config.KnowledgeBaseConfig{{Name: "Orders", Files: []string{"*.grl"}}})
+	ds, err = l.LintPaths([]string{dir})
+	if err != nil {
+		t.Fatalf("LintPaths failed: %v", err)
+	}
+	if hasRuleID(ds, "GRL018") {
+		t.Errorf("Did not expect GRL018 within the knowledge base: %v", ds.All())
+	}
+}
+
+// TestRules_RuleCycle tests GRL020 on rules that trigger each other.
+func TestRules_RuleCycle(t *testing.T) {
+	const pingPong = `
+rule ToPending "desc" salience 1 {
+    when Order.Status == "new"
+    then Order.Status = "pending";
+}
+
+rule ToNew "desc" salience 1 {
+    when Order.Status == "pending"
+    then Order.Status = "new";
+}
+`
+	l := linter.New()
+	ds := l.LintString("test.grl", pingPong)
+
+	var messages []string
+	for _, d := range ds.All() {
+		if d.RuleID == "GRL020" {
+			messages = append(messages, d.Message)
+		}
+	}
+	want := "ToPending (writes Order.Status) -> ToNew (writes Order.Status) -> ToPending"
+	if len(messages) != 1 || !strings.Contains(messages[0], want) {
+		t.Errorf("Expected one GRL020 with %q, got %v", want, messages)
+	}
+
+	ds = l.LintString("test.grl", strings.Replace(pingPong, `"new";`, `"new"; Retract("ToNew");`, 1))
+	if hasRuleID(ds, "GRL020") {
+		t.Error("Did not expect GRL020 when a rule of the cycle retracts")
+	}
+
+	// The rules of a cycle may be in different files of a knowledge base
+	dir := t.TempDir()
+	parts := strings.SplitAfter(pingPong, "}\n")
+	for i, name := range []string{"a.grl", "b.grl"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(parts[i]), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ds, err := l.LintPaths([]string{dir})
+	if err != nil {
+		t.Fatalf("LintPaths failed: %v", err)
+	}
+	if hasRuleID(ds, "GRL020") {
+		t.Error("Did not expect GRL020 across files outside a knowledge base")
+	}
+
+	l.SetKnowledgeBases([]config.KnowledgeBaseConfig{{Name: "Orders", Files: []string{"*.grl"}}})
+	ds, err = l.LintPaths([]string{dir})
+	if err != nil {
+		t.Fatalf("LintPaths failed: %v", err)
+	}
+	if !hasRuleID(ds, "GRL020") {
+		t.Error("Expected GRL020 across the files of a knowledge base")
+	}
+}